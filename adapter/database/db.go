@@ -1,34 +1,168 @@
 package database
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"strconv"
+	"time"
 
+	"github.com/XSAM/otelsql"
+	"github.com/go-sql-driver/mysql"
 	"github.com/jmoiron/sqlx"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 )
 
+// tlsConfigName is the name the custom TLS config is registered under with the MySQL driver.
+const tlsConfigName = "backscreen"
+
+const redacted = "[REDACTED]"
+
 type Config struct {
 	Host     string
 	Port     int
 	User     string
 	Password string
 	Database string
+	TLS      TLSConfig
+	Pool     PoolConfig
+	Retry    RetryConfig
+}
+
+type TLSConfig struct {
+	Enabled bool
+	// CA is the path to a PEM file with the certificate authority of the server.
+	CA string
+	// Cert and Key are paths to a PEM client certificate and its key, for mutual TLS.
+	Cert string
+	Key  string
+	// ServerName overrides the host name the server certificate is checked against.
+	ServerName string
+	// SkipVerify accepts any server certificate. Only meant for local setups.
+	SkipVerify bool
+}
+
+func (c TLSConfig) enabled() bool {
+	return c.Enabled || c.CA != "" || c.Cert != "" || c.SkipVerify
 }
 
+// DSN returns the data source name for the MySQL driver. It contains the password, so it must not be logged.
+func (c Config) DSN() (string, error) {
+	cfg, err := c.mysqlConfig()
+	if err != nil {
+		return "", err
+	}
+	return cfg.FormatDSN(), nil
+}
+
+// String returns the data source name with the password redacted.
 func (c Config) String() string {
-	return fmt.Sprintf(
-		"%s:%s@tcp(%s:%d)/%s?parseTime=true",
-		c.User,
-		c.Password,
-		c.Host,
-		c.Port,
-		c.Database,
-	)
-}
-
-func New(cfg *Config) (*sqlx.DB, error) {
-	db, err := sqlx.Connect("mysql", cfg.String())
+	if c.Password != "" {
+		c.Password = redacted
+	}
+
+	cfg := mysql.NewConfig()
+	c.applyTo(cfg)
+	if c.TLS.enabled() {
+		cfg.TLSConfig = tlsConfigName
+	}
+
+	return cfg.FormatDSN()
+}
+
+// LogValue keeps the password out of structured logs.
+func (c Config) LogValue() slog.Value {
+	return slog.StringValue(c.String())
+}
+
+func (c Config) applyTo(cfg *mysql.Config) {
+	cfg.User = c.User
+	cfg.Passwd = c.Password
+	cfg.Net = "tcp"
+	cfg.Addr = net.JoinHostPort(c.Host, strconv.Itoa(c.Port))
+	cfg.DBName = c.Database
+	cfg.ParseTime = true
+	// DATETIME columns have no zone, so times are always written and read as UTC,
+	// including the ones MySQL sets itself like created_at.
+	cfg.Loc = time.UTC
+	cfg.Params = map[string]string{"time_zone": "'+00:00'"}
+}
+
+func (c Config) mysqlConfig() (*mysql.Config, error) {
+	cfg := mysql.NewConfig()
+	c.applyTo(cfg)
+
+	if c.TLS.enabled() {
+		tlsConfig, err := c.TLS.build(c.Host)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := mysql.RegisterTLSConfig(tlsConfigName, tlsConfig); err != nil {
+			return nil, fmt.Errorf("failed to register TLS config: %w", err)
+		}
+		cfg.TLSConfig = tlsConfigName
+	}
+
+	return cfg, nil
+}
+
+func (c TLSConfig) build(host string) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		ServerName:         host,
+		InsecureSkipVerify: c.SkipVerify,
+		MinVersion:         tls.VersionTLS12,
+	}
+	if c.ServerName != "" {
+		tlsConfig.ServerName = c.ServerName
+	}
+
+	if c.CA != "" {
+		pem, err := os.ReadFile(c.CA)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read database CA: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in database CA %s", c.CA)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if c.Cert != "" || c.Key != "" {
+		cert, err := tls.LoadX509KeyPair(c.Cert, c.Key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load database client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+func New(ctx context.Context, cfg *Config) (*sqlx.DB, error) {
+	dsn, err := cfg.DSN()
+	if err != nil {
+		return nil, err
+	}
+
+	// Every query gets a span, so slow syncs can be traced down to the statement.
+	sqlDB, err := otelsql.Open("mysql", dsn, otelsql.WithAttributes(semconv.DBSystemMySQL))
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to database: %w", err)
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	cfg.Pool.apply(sqlDB)
+
+	db := sqlx.NewDb(sqlDB, "mysql")
+	if err := ping(ctx, db, cfg.Retry); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to connect to database %s: %w", cfg, err)
 	}
 
 	return db, nil
@@ -0,0 +1,53 @@
+package database
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestConfigRedactsPassword(t *testing.T) {
+	cfg := Config{Host: "db", Port: 3306, User: "root", Password: "hunter2", Database: "backscreen_home"}
+
+	for name, got := range map[string]string{
+		"String":   cfg.String(),
+		"Sprintf":  fmt.Sprintf("%v", cfg),
+		"pointer":  fmt.Sprintf("%s", &cfg),
+		"LogValue": slog.AnyValue(cfg).Resolve().String(),
+	} {
+		if strings.Contains(got, "hunter2") {
+			t.Errorf("Expected %s to redact the password, got %q", name, got)
+		}
+	}
+
+	want := "root:[REDACTED]@tcp(db:3306)/backscreen_home?parseTime=true&time_zone=%27%2B00%3A00%27"
+	if got := cfg.String(); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+
+	dsn, err := cfg.DSN()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.Contains(dsn, "root:hunter2@") {
+		t.Errorf("Expected DSN to contain the password, got %q", dsn)
+	}
+}
+
+func TestConfigTLS(t *testing.T) {
+	cfg := Config{Host: "db", Port: 3306, User: "root", Database: "backscreen_home", TLS: TLSConfig{SkipVerify: true}}
+
+	dsn, err := cfg.DSN()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.Contains(dsn, "tls="+tlsConfigName) {
+		t.Errorf("Expected DSN to use the TLS config, got %q", dsn)
+	}
+
+	cfg.TLS = TLSConfig{CA: "testdata/missing.pem"}
+	if _, err := cfg.DSN(); err == nil {
+		t.Error("Expected an error for a missing CA file, got nil")
+	}
+}
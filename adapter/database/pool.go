@@ -0,0 +1,124 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type PoolConfig struct {
+	// MaxOpenConns limits the connections to the database, zero means unlimited.
+	MaxOpenConns int
+	MaxIdleConns int
+	// ConnMaxLifetime should be shorter than the wait_timeout of the server,
+	// so connections are not closed under the pool.
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+}
+
+func (c PoolConfig) apply(db *sql.DB) {
+	db.SetMaxOpenConns(c.MaxOpenConns)
+	db.SetMaxIdleConns(c.MaxIdleConns)
+	db.SetConnMaxLifetime(c.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(c.ConnMaxIdleTime)
+}
+
+type RetryConfig struct {
+	// Timeout is how long connecting is retried for, zero tries only once.
+	Timeout time.Duration
+	// InitialBackoff is doubled after every failed attempt up to MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+func (c RetryConfig) backoff(attempt int) time.Duration {
+	backoff := c.InitialBackoff
+	for range attempt {
+		backoff *= 2
+		if backoff >= c.MaxBackoff {
+			return c.MaxBackoff
+		}
+	}
+	return backoff
+}
+
+// ping retries until the database answers, so the service can start together with MySQL.
+func ping(ctx context.Context, db *sqlx.DB, cfg RetryConfig) error {
+	logger := slog.With("component", "database")
+
+	if cfg.Timeout <= 0 {
+		return db.PingContext(ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+
+	for attempt := 0; ; attempt++ {
+		err := db.PingContext(ctx)
+		if err == nil {
+			return nil
+		}
+
+		backoff := cfg.backoff(attempt)
+		if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) < backoff {
+			return err
+		}
+
+		logger.WarnContext(ctx, "Database not reachable, retrying",
+			slog.Int("attempt", attempt+1),
+			slog.Duration("backoff", backoff),
+			slog.Any("error", err),
+		)
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+	}
+}
+
+// Monitor logs a warning when the pool is saturated, i.e. every connection is in use or
+// queries had to wait for a connection since the last check. It returns when ctx is done.
+func Monitor(ctx context.Context, db *sql.DB, interval time.Duration) {
+	logger := slog.With("component", "database")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	prev := db.Stats()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		stats := db.Stats()
+		if saturated(prev, stats) {
+			logger.WarnContext(ctx, "Database connection pool saturated",
+				slog.Int("max_open", stats.MaxOpenConnections),
+				slog.Int("in_use", stats.InUse),
+				slog.Int("idle", stats.Idle),
+				slog.Int64("waits", stats.WaitCount-prev.WaitCount),
+				slog.Duration("wait_duration", stats.WaitDuration-prev.WaitDuration),
+			)
+		} else {
+			logger.DebugContext(ctx, "Database connection pool",
+				slog.Int("in_use", stats.InUse),
+				slog.Int("idle", stats.Idle),
+			)
+		}
+		prev = stats
+	}
+}
+
+func saturated(prev, stats sql.DBStats) bool {
+	if stats.WaitCount > prev.WaitCount {
+		return true
+	}
+	return stats.MaxOpenConnections > 0 && stats.InUse >= stats.MaxOpenConnections
+}
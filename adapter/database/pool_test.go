@@ -0,0 +1,41 @@
+package database
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+)
+
+func TestRetryBackoff(t *testing.T) {
+	cfg := RetryConfig{InitialBackoff: 500 * time.Millisecond, MaxBackoff: 3 * time.Second}
+
+	want := []time.Duration{500 * time.Millisecond, time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second}
+	for attempt, expected := range want {
+		if got := cfg.backoff(attempt); got != expected {
+			t.Errorf("Expected backoff %s for attempt %d, got %s", expected, attempt, got)
+		}
+	}
+}
+
+func TestSaturated(t *testing.T) {
+	tests := []struct {
+		name  string
+		prev  sql.DBStats
+		stats sql.DBStats
+		want  bool
+	}{
+		{name: "idle", stats: sql.DBStats{MaxOpenConnections: 10, InUse: 2, Idle: 3}, want: false},
+		{name: "all in use", stats: sql.DBStats{MaxOpenConnections: 10, InUse: 10}, want: true},
+		{name: "unlimited", stats: sql.DBStats{InUse: 50}, want: false},
+		{name: "new waits", prev: sql.DBStats{WaitCount: 3}, stats: sql.DBStats{MaxOpenConnections: 10, InUse: 4, WaitCount: 5}, want: true},
+		{name: "old waits", prev: sql.DBStats{WaitCount: 5}, stats: sql.DBStats{MaxOpenConnections: 10, InUse: 4, WaitCount: 5}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := saturated(tt.prev, tt.stats); got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
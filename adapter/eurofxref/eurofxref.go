@@ -0,0 +1,130 @@
+// Package eurofxref fetches the euro foreign exchange reference rates straight from the ECB.
+package eurofxref
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/zemzale/backscreen-home/domain/entity"
+	"github.com/zemzale/backscreen-home/domain/mapper"
+	"github.com/zemzale/backscreen-home/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// DailyURL only has the latest reference date, which is all a regular sync needs.
+const DailyURL = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml"
+
+var tracer = telemetry.Tracer("github.com/zemzale/backscreen-home/adapter/eurofxref")
+
+type Fetcher struct {
+	httpClient *http.Client
+	url        string
+	// lenient skips days that can't be parsed instead of failing the fetch.
+	lenient bool
+}
+
+type Option func(*Fetcher)
+
+// WithLenientParsing keeps the rates of the days that could be parsed when others are malformed.
+func WithLenientParsing() Option {
+	return func(f *Fetcher) {
+		f.lenient = true
+	}
+}
+
+// WithURL fetches another eurofxref feed, e.g. eurofxref-hist-90d.xml.
+func WithURL(url string) Option {
+	return func(f *Fetcher) {
+		f.url = url
+	}
+}
+
+func New(httpClient *http.Client, opts ...Option) *Fetcher {
+	f := &Fetcher{
+		httpClient: httpClient,
+		url:        DailyURL,
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+func (f Fetcher) Name() string {
+	return "ecb"
+}
+
+func (f Fetcher) Fetch(ctx context.Context) (_ entity.Feed, err error) {
+	ctx, span := tracer.Start(ctx, "eurofxref.Fetch")
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	logger := slog.With("component", "EurofxrefRateFetcher")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.url, http.NoBody)
+	if err != nil {
+		return entity.Feed{}, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	logger.InfoContext(ctx, "Sending request for exchange rates", slog.String("url", f.url))
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return entity.Feed{}, fmt.Errorf("failed to fetch rates: %w", err)
+	}
+	defer resp.Body.Close()
+
+	logger.InfoContext(
+		ctx,
+		"Received response for exchange rates",
+		slog.String("url", f.url),
+		slog.Int("status", resp.StatusCode),
+	)
+	if resp.StatusCode != http.StatusOK {
+		return entity.Feed{}, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	parseCtx, parseSpan := tracer.Start(ctx, "mapper.FeedFromEurofxref", trace.WithAttributes(attribute.Bool("lenient", f.lenient)))
+	feed, err := f.parse(parseCtx, logger, resp.Body)
+	parseSpan.SetAttributes(attribute.Int("item_count", len(feed.Items)))
+	if err != nil {
+		parseSpan.RecordError(err)
+		parseSpan.SetStatus(codes.Error, err.Error())
+	}
+	parseSpan.End()
+	if err != nil {
+		return entity.Feed{}, fmt.Errorf("failed to parse rates: %w", err)
+	}
+	feed.Source = f.Name()
+
+	logger.DebugContext(ctx, "Parsed feed", slog.Int("item_count", len(feed.Items)))
+
+	return feed, nil
+}
+
+func (f Fetcher) parse(ctx context.Context, logger *slog.Logger, r io.Reader) (entity.Feed, error) {
+	feed, itemErrs, err := mapper.FeedFromEurofxref(r)
+	if err != nil {
+		return entity.Feed{}, err
+	}
+
+	if !f.lenient && len(itemErrs) > 0 {
+		return entity.Feed{}, itemErrs[0]
+	}
+
+	for _, itemErr := range itemErrs {
+		logger.WarnContext(ctx, "Skipped malformed feed day", slog.Any("error", itemErr))
+		trace.SpanFromContext(ctx).AddEvent("skipped item", trace.WithAttributes(attribute.String("error", itemErr.Error())))
+	}
+
+	return feed, nil
+}
@@ -3,26 +3,61 @@ package lvbank
 import (
 	"context"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 
 	"github.com/zemzale/backscreen-home/domain/entity"
 	"github.com/zemzale/backscreen-home/domain/mapper"
-	"github.com/zemzale/backscreen-home/slices"
+	"github.com/zemzale/backscreen-home/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var tracer = telemetry.Tracer("github.com/zemzale/backscreen-home/adapter/lvbank")
+
 type Fetcher struct {
 	httpClient *http.Client
+	// lenient skips feed items that can't be parsed instead of failing the fetch.
+	lenient bool
+}
+
+type Option func(*Fetcher)
+
+// WithLenientParsing keeps the rates of the items that could be parsed when others are malformed.
+func WithLenientParsing() Option {
+	return func(f *Fetcher) {
+		f.lenient = true
+	}
 }
 
-func New(httpClient *http.Client) *Fetcher {
-	return &Fetcher{
+func New(httpClient *http.Client, opts ...Option) *Fetcher {
+	f := &Fetcher{
 		httpClient: httpClient,
 	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
 }
 
-func (f Fetcher) Fetch(ctx context.Context, currency string) ([]entity.Rate, error) {
-	logger := slog.With("component", "LVBankRSSRateFetcher", "currency", currency)
+func (f Fetcher) Name() string {
+	return "lvbank"
+}
+
+// Fetch downloads the feed once with the rates of every currency.
+func (f Fetcher) Fetch(ctx context.Context) (_ entity.Feed, err error) {
+	ctx, span := tracer.Start(ctx, "lvbank.Fetch")
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	logger := slog.With("component", "LVBankRSSRateFetcher")
 	logger.DebugContext(ctx, "Fetching rates")
 
 	url := "https://www.bank.lv/vk/ecb_rss.xml"
@@ -30,7 +65,7 @@ func (f Fetcher) Fetch(ctx context.Context, currency string) ([]entity.Rate, err
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return entity.Feed{}, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// TODO: Change out the default client, since it's very bad and has no timeouts
@@ -38,7 +73,7 @@ func (f Fetcher) Fetch(ctx context.Context, currency string) ([]entity.Rate, err
 	logger.InfoContext(ctx, "Sending request for exchange rates", slog.String("url", url))
 	resp, err := f.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch rates: %w", err)
+		return entity.Feed{}, fmt.Errorf("failed to fetch rates: %w", err)
 	}
 	defer resp.Body.Close()
 
@@ -49,18 +84,46 @@ func (f Fetcher) Fetch(ctx context.Context, currency string) ([]entity.Rate, err
 		slog.Int("status", resp.StatusCode),
 	)
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return entity.Feed{}, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
 
 	logger.DebugContext(ctx, "Parsing rates")
-	rates, err := mapper.RatesFromXML(resp.Body)
+	parseCtx, parseSpan := tracer.Start(ctx, "mapper.FeedFromXML", trace.WithAttributes(attribute.Bool("lenient", f.lenient)))
+	feed, err := f.parse(parseCtx, logger, resp.Body)
+	parseSpan.SetAttributes(attribute.Int("item_count", len(feed.Items)))
+	if err != nil {
+		parseSpan.RecordError(err)
+		parseSpan.SetStatus(codes.Error, err.Error())
+	}
+	parseSpan.End()
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse rates: %w", err)
+		return entity.Feed{}, fmt.Errorf("failed to parse rates: %w", err)
 	}
+	feed.Source = f.Name()
 
-	logger.DebugContext(ctx, "Searchign for rate in response", slog.Int("rate_count", len(rates)))
+	logger.DebugContext(ctx, "Parsed feed",
+		slog.Int("item_count", len(feed.Items)),
+		slog.Time("build_date", feed.BuildDate),
+		slog.Duration("ttl", feed.TTL),
+	)
+
+	return feed, nil
+}
+
+func (f Fetcher) parse(ctx context.Context, logger *slog.Logger, r io.Reader) (entity.Feed, error) {
+	feed, itemErrs, err := mapper.FeedFromXML(r)
+	if err != nil {
+		return entity.Feed{}, err
+	}
+
+	if !f.lenient && len(itemErrs) > 0 {
+		return entity.Feed{}, itemErrs[0]
+	}
+
+	for _, itemErr := range itemErrs {
+		logger.WarnContext(ctx, "Skipped malformed feed item", slog.Any("error", itemErr))
+		trace.SpanFromContext(ctx).AddEvent("skipped item", trace.WithAttributes(attribute.String("error", itemErr.Error())))
+	}
 
-	return slices.FilterInPlace(rates, func(r entity.Rate) bool {
-		return r.Code == currency
-	}), nil
+	return feed, nil
 }
@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/zemzale/backscreen-home/domain/entity"
+	"github.com/zemzale/backscreen-home/domain/usecase/quarantine"
+	"github.com/zemzale/backscreen-home/pkg/logging"
+	"github.com/zemzale/backscreen-home/pkg/middleware"
+	"github.com/zemzale/backscreen-home/pkg/server"
+	"github.com/zemzale/backscreen-home/slices"
+	"github.com/zemzale/backscreen-home/storage"
+)
+
+// Get log levels
+// (GET /api/v1/admin/log-level)
+func (a api) GetApiV1AdminLogLevel(ctx context.Context, req server.GetApiV1AdminLogLevelRequestObject) (server.GetApiV1AdminLogLevelResponseObject, error) {
+	return server.GetApiV1AdminLogLevel200JSONResponse(currentLogLevels()), nil
+}
+
+// Change log levels at runtime
+// (PUT /api/v1/admin/log-level)
+func (a api) PutApiV1AdminLogLevel(ctx context.Context, req server.PutApiV1AdminLogLevelRequestObject) (server.PutApiV1AdminLogLevelResponseObject, error) {
+	logger := slog.With("component", "api")
+
+	badRequest := func(err error) (server.PutApiV1AdminLogLevelResponseObject, error) {
+		errStr := err.Error()
+		return server.PutApiV1AdminLogLevel400JSONResponse{
+			BadRequestJSONResponse: server.BadRequestJSONResponse{Error: &errStr},
+		}, nil
+	}
+
+	// Everything is validated first, so a bad request doesn't apply half of the changes.
+	var level *slog.Level
+	if req.Body.Level != nil {
+		parsed, err := logging.ParseLevel(*req.Body.Level)
+		if err != nil {
+			return badRequest(err)
+		}
+		level = &parsed
+	}
+
+	components := map[string]*slog.Level{}
+	if req.Body.Components != nil {
+		for component, levelStr := range *req.Body.Components {
+			if strings.TrimSpace(levelStr) == "" {
+				components[component] = nil
+				continue
+			}
+
+			parsed, err := logging.ParseLevel(levelStr)
+			if err != nil {
+				return badRequest(err)
+			}
+			components[component] = &parsed
+		}
+	}
+
+	if level != nil {
+		logLevels.SetLevel(*level)
+	}
+	for component, level := range components {
+		if level == nil {
+			logLevels.ResetComponentLevel(component)
+			continue
+		}
+		logLevels.SetComponentLevel(component, *level)
+	}
+
+	levels := currentLogLevels()
+	logger.InfoContext(ctx, "Changed log levels", slog.String("level", *levels.Level), slog.Any("components", *levels.Components))
+
+	return server.PutApiV1AdminLogLevel200JSONResponse(levels), nil
+}
+
+func currentLogLevels() server.LogLevels {
+	level := strings.ToLower(logLevels.Level().String())
+
+	components := map[string]string{}
+	for component, level := range logLevels.Components() {
+		components[component] = strings.ToLower(level.String())
+	}
+
+	return server.LogLevels{
+		Level:      &level,
+		Components: &components,
+	}
+}
+
+// List quarantined rates
+// (GET /api/v1/admin/quarantine)
+func (a api) GetApiV1AdminQuarantine(ctx context.Context, req server.GetApiV1AdminQuarantineRequestObject) (server.GetApiV1AdminQuarantineResponseObject, error) {
+	status := entity.QuarantinePending
+	if req.Params.Status != nil {
+		var err error
+		status, err = entity.ParseQuarantineStatus(string(*req.Params.Status))
+		if err != nil {
+			return server.GetApiV1AdminQuarantine400JSONResponse{BadRequestJSONResponse: errToBadRequest(err)}, nil
+		}
+	}
+
+	rates, err := quarantine.New(a.store).List(ctx, status)
+	if err != nil {
+		return server.GetApiV1AdminQuarantine500JSONResponse{
+			InternalServerErrorJSONResponse: errToInternalServerError(err),
+		}, nil
+	}
+
+	return server.GetApiV1AdminQuarantine200JSONResponse(slices.Map(rates, mapQuarantinedRate)), nil
+}
+
+// Approve a quarantined rate
+// (POST /api/v1/admin/quarantine/{id}/approve)
+func (a api) PostApiV1AdminQuarantineIdApprove(ctx context.Context, req server.PostApiV1AdminQuarantineIdApproveRequestObject) (server.PostApiV1AdminQuarantineIdApproveResponseObject, error) {
+	rate, err := quarantine.New(a.store).Approve(ctx, req.Id, reviewer(ctx), req.Body.Reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			return server.PostApiV1AdminQuarantineIdApprove404Response{}, nil
+		case errors.Is(err, storage.ErrAlreadyReviewed), errors.Is(err, storage.ErrDuplicate):
+			return server.PostApiV1AdminQuarantineIdApprove409JSONResponse{
+				ReviewConflictJSONResponse: reviewConflict(rate, err),
+			}, nil
+		case errors.Is(err, quarantine.ErrReasonRequired):
+			errStr := err.Error()
+			return server.PostApiV1AdminQuarantineIdApprove400JSONResponse{
+				BadRequestJSONResponse: server.BadRequestJSONResponse{Error: &errStr},
+			}, nil
+		}
+
+		return server.PostApiV1AdminQuarantineIdApprove500JSONResponse{
+			InternalServerErrorJSONResponse: errToInternalServerError(err),
+		}, nil
+	}
+
+	return server.PostApiV1AdminQuarantineIdApprove200JSONResponse(mapQuarantinedRate(rate)), nil
+}
+
+// Reject a quarantined rate
+// (POST /api/v1/admin/quarantine/{id}/reject)
+func (a api) PostApiV1AdminQuarantineIdReject(ctx context.Context, req server.PostApiV1AdminQuarantineIdRejectRequestObject) (server.PostApiV1AdminQuarantineIdRejectResponseObject, error) {
+	rate, err := quarantine.New(a.store).Reject(ctx, req.Id, reviewer(ctx), req.Body.Reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			return server.PostApiV1AdminQuarantineIdReject404Response{}, nil
+		case errors.Is(err, storage.ErrAlreadyReviewed):
+			return server.PostApiV1AdminQuarantineIdReject409JSONResponse{
+				ReviewConflictJSONResponse: reviewConflict(rate, err),
+			}, nil
+		case errors.Is(err, quarantine.ErrReasonRequired):
+			errStr := err.Error()
+			return server.PostApiV1AdminQuarantineIdReject400JSONResponse{
+				BadRequestJSONResponse: server.BadRequestJSONResponse{Error: &errStr},
+			}, nil
+		}
+
+		return server.PostApiV1AdminQuarantineIdReject500JSONResponse{
+			InternalServerErrorJSONResponse: errToInternalServerError(err),
+		}, nil
+	}
+
+	return server.PostApiV1AdminQuarantineIdReject200JSONResponse(mapQuarantinedRate(rate)), nil
+}
+
+// reviewConflict explains why the rate couldn't be reviewed, along with its current state.
+func reviewConflict(rate entity.QuarantinedRate, err error) server.ReviewConflictJSONResponse {
+	return server.ReviewConflictJSONResponse{
+		Error:           err.Error(),
+		QuarantinedRate: mapQuarantinedRate(rate),
+	}
+}
+
+// reviewer names the API key that reviewed a quarantined rate.
+func reviewer(ctx context.Context) string {
+	key, ok := middleware.APIKeyFromContext(ctx)
+	if !ok {
+		return "api"
+	}
+	return fmt.Sprintf("%s (key %d)", key.Name, key.ID)
+}
+
+func mapQuarantinedRate(rate entity.QuarantinedRate) server.QuarantinedRate {
+	mapped := server.QuarantinedRate{
+		Id:         rate.ID,
+		Rate:       mapRateToApiV1CurrencyHistoryRate(rate.Rate),
+		Source:     rate.Source,
+		Reason:     rate.Reason,
+		Status:     server.QuarantinedRateStatus(rate.Status),
+		CreatedAt:  rate.CreatedAt,
+		ReviewedAt: rate.ReviewedAt,
+	}
+
+	if rate.ReviewedAt != nil {
+		mapped.ReviewedBy = &rate.ReviewedBy
+		mapped.ReviewReason = &rate.ReviewReason
+	}
+
+	return mapped
+}
@@ -4,19 +4,32 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/httplog/v3"
+	openapi_types "github.com/oapi-codegen/runtime/types"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/zemzale/backscreen-home/domain/entity"
+	"github.com/zemzale/backscreen-home/domain/mapper"
+	"github.com/zemzale/backscreen-home/domain/usecase/apikeys"
+	"github.com/zemzale/backscreen-home/domain/usecase/exporter"
+	"github.com/zemzale/backscreen-home/domain/usecase/rebase"
+	"github.com/zemzale/backscreen-home/domain/usecase/status"
+	"github.com/zemzale/backscreen-home/pkg/cache"
+	"github.com/zemzale/backscreen-home/pkg/metrics"
+	"github.com/zemzale/backscreen-home/pkg/middleware"
 	"github.com/zemzale/backscreen-home/pkg/server"
+	"github.com/zemzale/backscreen-home/pkg/telemetry"
 	"github.com/zemzale/backscreen-home/slices"
 	"github.com/zemzale/backscreen-home/storage"
 )
@@ -25,7 +38,7 @@ var apiCmd = &cobra.Command{
 	Use:   "api",
 	Short: "Start the API",
 	Long:  `Start the API to get stored currency exchange rates.`,
-	RunE: func(cmd *cobra.Command, args []string) error {
+	RunE: withStore(func(cmd *cobra.Command, args []string, store *storage.Client) error {
 		ctx := cmd.Context()
 		logger := slog.With("component", "api")
 
@@ -33,15 +46,75 @@ var apiCmd = &cobra.Command{
 
 		logger.InfoContext(ctx, "Starting API", slog.String("host", host))
 
+		defaultLimit, err := middleware.ParseLimit(viper.GetString("api.ratelimit.default"))
+		if err != nil {
+			return fmt.Errorf("invalid api.ratelimit.default: %w", err)
+		}
+
+		routeLimits, err := middleware.ParseRouteLimits(viper.GetString("api.ratelimit.routes"))
+		if err != nil {
+			return fmt.Errorf("invalid api.ratelimit.routes: %w", err)
+		}
+
+		ipLimit, err := middleware.ParseLimit(viper.GetString("api.ratelimit.ip"))
+		if err != nil {
+			return fmt.Errorf("invalid api.ratelimit.ip: %w", err)
+		}
+
 		mux := chi.NewRouter()
+		if viper.GetBool("api.trust_proxy") {
+			// Only trust X-Forwarded-For and X-Real-IP behind a proxy, otherwise clients could pick their own rate limit key.
+			mux.Use(chimiddleware.RealIP)
+		}
+		mux.Use(telemetry.Middleware)
 		mux.Use(httplog.RequestLogger(logger, &httplog.Options{
 			Level:         slog.LevelDebug,
 			Schema:        httplog.SchemaECS,
 			RecoverPanics: true,
 		}))
-		handler := server.HandlerFromMux(
-			server.NewStrictHandler(api{store: store}, nil),
-			mux,
+		mux.Use(metrics.Middleware)
+
+		// The generated server wraps the handler with these in reverse order, so the IP limit runs
+		// first, then authentication and the limit per key.
+		middlewares := []server.MiddlewareFunc{}
+		auth := middleware.APIKeyAuth(apikeys.New(store))
+		if viper.GetBool("api.ratelimit.enabled") {
+			limiter := middleware.NewMemoryLimiter()
+			middlewares = append(middlewares,
+				middleware.RateLimit(limiter, defaultLimit, routeLimits),
+				auth,
+				middleware.IPRateLimit(limiter, ipLimit),
+			)
+		} else {
+			middlewares = append(middlewares, auth)
+		}
+
+		var rates rateReader = store
+		if viper.GetBool("api.cache.enabled") {
+			cached := storage.NewCachedReader(store, viper.GetInt("api.cache.size"), viper.GetDuration("api.cache.ttl"))
+			go cached.Watch(ctx, viper.GetDuration("api.cache.poll_interval"))
+			metrics.RegisterCache("latest", func() cache.Stats { latest, _ := cached.Stats(); return latest })
+			metrics.RegisterCache("history", func() cache.Stats { _, history := cached.Stats(); return history })
+			rates = cached
+		}
+
+		mux.Handle("/metrics", metrics.Handler())
+		health{
+			store:      store,
+			status:     status.New(store, viper.GetDuration("api.status.grace")),
+			maxDataAge: viper.GetDuration("api.readiness.max_data_age"),
+		}.Mount(mux)
+
+		handler := server.HandlerWithOptions(
+			server.NewStrictHandler(
+				api{store: store, rates: rates, rebase: rebase.New(rates), exporter: exporter.New(store)},
+				// Strict middlewares are wrapped in reverse order as well, caching sees the JSON response first.
+				[]server.StrictMiddlewareFunc{cacheMiddleware(viper.GetDuration("api.cache.stale_max_age")), negotiateMiddleware},
+			),
+			server.ChiServerOptions{
+				BaseRouter:  mux,
+				Middlewares: middlewares,
+			},
 		)
 
 		errChan := make(chan error, 1)
@@ -82,19 +155,63 @@ var apiCmd = &cobra.Command{
 		}
 
 		return nil
-	},
+	}),
+}
+
+func init() {
+	flags := apiCmd.Flags()
+	flags.String("host", "127.0.0.1:8080", "address the API listens on")
+	flags.Bool("trust-proxy", false, "use X-Forwarded-For and X-Real-IP for the client address")
+	flags.Bool("ratelimit", true, "enable rate limiting")
+	flags.Bool("cache", true, "enable the in-process read cache")
+
+	_ = viper.BindPFlag("api.host", flags.Lookup("host"))
+	_ = viper.BindPFlag("api.trust_proxy", flags.Lookup("trust-proxy"))
+	_ = viper.BindPFlag("api.ratelimit.enabled", flags.Lookup("ratelimit"))
+	_ = viper.BindPFlag("api.cache.enabled", flags.Lookup("cache"))
+
+	viper.SetDefault("api.ratelimit.default", "5:20")
+	viper.SetDefault("api.ratelimit.routes", "/api/v1/{currency}/history=1:10,/api/v1/export=0.1:2")
+	viper.SetDefault("api.ratelimit.ip", "20:100")
+	viper.SetDefault("api.cache.size", 1000)
+	viper.SetDefault("api.cache.ttl", 10*time.Minute)
+	viper.SetDefault("api.cache.poll_interval", 30*time.Second)
+	viper.SetDefault("api.cache.stale_max_age", 5*time.Minute)
+	viper.SetDefault("api.status.grace", 2*time.Hour)
+	viper.SetDefault("api.readiness.max_data_age", 0)
 }
 
 var _ server.StrictServerInterface = &api{}
 
+// rateReader is implemented by both the storage client and its cache.
+type rateReader interface {
+	GetLatestRate(ctx context.Context, series entity.Series) (entity.Rate, error)
+	GetRates(ctx context.Context, series entity.Series) ([]entity.Rate, error)
+}
+
 type api struct {
-	store *storage.Client
+	store    *storage.Client
+	rates    rateReader
+	rebase   *rebase.Usecase
+	exporter *exporter.Usecase
 }
 
 // Get latest exchange rate
 // (GET /api/v1/{currency})
 func (a api) GetApiV1Currency(ctx context.Context, req server.GetApiV1CurrencyRequestObject) (server.GetApiV1CurrencyResponseObject, error) {
-	rate, err := a.store.GetLatestRate(ctx, req.Currency)
+	series, err := requestedSeries(req.Currency, req.Params.Base, req.Params.Type)
+	if err != nil {
+		return server.GetApiV1Currency400JSONResponse{BadRequestJSONResponse: errToBadRequest(err)}, nil
+	}
+
+	mapRate := mapRateToApiV1CurrencyHistoryRate
+
+	rate, err := a.rates.GetLatestRate(ctx, series)
+	if errors.Is(err, storage.ErrNotFound) && series.Base != entity.DefaultBase {
+		// Stored rates against the base win, others are computed from the EUR rates.
+		rate, err = a.rebase.Latest(ctx, series)
+		mapRate = mapComputedRate
+	}
 	if err != nil {
 		if errors.Is(err, storage.ErrNotFound) {
 			return server.GetApiV1Currency404Response{}, nil
@@ -105,17 +222,24 @@ func (a api) GetApiV1Currency(ctx context.Context, req server.GetApiV1CurrencyRe
 		}, nil
 	}
 
-	return server.GetApiV1Currency200JSONResponse{
-		Code:        rate.Code,
-		PublishedAt: rate.PublishedAt,
-		Value:       rate.Value,
-	}, nil
+	return server.GetApiV1Currency200JSONResponse(mapRate(rate)), nil
 }
 
 // Get all historical exchange rates
 // (GET /api/v1/{currency}/history)
 func (a api) GetApiV1CurrencyHistory(ctx context.Context, req server.GetApiV1CurrencyHistoryRequestObject) (server.GetApiV1CurrencyHistoryResponseObject, error) {
-	rates, err := a.store.GetRates(ctx, req.Currency)
+	series, err := requestedSeries(req.Currency, req.Params.Base, req.Params.Type)
+	if err != nil {
+		return server.GetApiV1CurrencyHistory400JSONResponse{BadRequestJSONResponse: errToBadRequest(err)}, nil
+	}
+
+	mapRate := mapRateToApiV1CurrencyHistoryRate
+
+	rates, err := a.rates.GetRates(ctx, series)
+	if (errors.Is(err, storage.ErrNotFound) || err == nil && len(rates) == 0) && series.Base != entity.DefaultBase {
+		rates, err = a.rebase.History(ctx, series)
+		mapRate = mapComputedRate
+	}
 	if err != nil {
 		if errors.Is(err, storage.ErrNotFound) {
 			return server.GetApiV1Currency404Response{}, nil
@@ -126,17 +250,189 @@ func (a api) GetApiV1CurrencyHistory(ctx context.Context, req server.GetApiV1Cur
 		}, nil
 	}
 
-	ratesResponse := slices.Map(rates, mapRateToApiV1CurrencyHistoryRate)
+	ratesResponse := slices.Map(rates, mapRate)
 
 	return server.GetApiV1CurrencyHistory200JSONResponse(ratesResponse), nil
 }
 
+// Get the source values behind an exchange rate
+// (GET /api/v1/{currency}/sources)
+func (a api) GetApiV1CurrencySources(ctx context.Context, req server.GetApiV1CurrencySourcesRequestObject) (server.GetApiV1CurrencySourcesResponseObject, error) {
+	series, err := requestedSeries(req.Currency, req.Params.Base, req.Params.Type)
+	if err != nil {
+		return server.GetApiV1CurrencySources400JSONResponse{BadRequestJSONResponse: errToBadRequest(err)}, nil
+	}
+
+	var (
+		rate   *server.Rate
+		date   time.Time
+		stored entity.Rate
+	)
+
+	if req.Params.Date != nil {
+		date = req.Params.Date.Time
+		stored, err = a.store.GetRate(ctx, series, date)
+	} else {
+		stored, err = a.rates.GetLatestRate(ctx, series)
+		date = stored.Date
+	}
+
+	switch {
+	case err == nil:
+		mapped := mapRateToApiV1CurrencyHistoryRate(stored)
+		rate = &mapped
+	case errors.Is(err, storage.ErrNotFound) && req.Params.Date != nil:
+		// A rate the sources disagreed on is quarantined, but its source values are still kept.
+	case errors.Is(err, storage.ErrNotFound):
+		return server.GetApiV1CurrencySources404Response{}, nil
+	default:
+		return server.GetApiV1CurrencySources500JSONResponse{
+			InternalServerErrorJSONResponse: errToInternalServerError(err),
+		}, nil
+	}
+
+	sources, err := a.store.GetSourceRates(ctx, series, date)
+	if err != nil {
+		return server.GetApiV1CurrencySources500JSONResponse{
+			InternalServerErrorJSONResponse: errToInternalServerError(err),
+		}, nil
+	}
+
+	if rate == nil && len(sources) == 0 {
+		return server.GetApiV1CurrencySources404Response{}, nil
+	}
+
+	return server.GetApiV1CurrencySources200JSONResponse{
+		Base:    series.Base,
+		Code:    req.Currency,
+		Type:    server.RateType(series.Type),
+		Date:    openapi_types.Date{Time: date},
+		Rate:    rate,
+		Sources: slices.Map(sources, mapSourceRate),
+	}, nil
+}
+
+// Export historical exchange rates
+// (GET /api/v1/export)
+func (a api) GetApiV1Export(ctx context.Context, req server.GetApiV1ExportRequestObject) (server.GetApiV1ExportResponseObject, error) {
+	format := exporter.FormatCSV
+	if req.Params.Format != nil {
+		var err error
+		format, err = exporter.ParseFormat(string(*req.Params.Format))
+		if err != nil {
+			return server.GetApiV1Export400JSONResponse{BadRequestJSONResponse: errToBadRequest(err)}, nil
+		}
+	}
+
+	filter := storage.RateFilter{}
+	if req.Params.Currency != nil {
+		filter.Codes = *req.Params.Currency
+	}
+	if req.Params.Base != nil {
+		filter.Bases = []string{*req.Params.Base}
+	}
+	if req.Params.Type != nil {
+		rateType, err := entity.ParseRateType(*req.Params.Type)
+		if err != nil {
+			return server.GetApiV1Export400JSONResponse{BadRequestJSONResponse: errToBadRequest(err)}, nil
+		}
+		filter.Types = []entity.RateType{rateType}
+	}
+	if req.Params.From != nil {
+		filter.From = req.Params.From.Time
+	}
+	if req.Params.To != nil {
+		// The range is inclusive for the caller, but the filter upper bound is exclusive.
+		filter.To = req.Params.To.Time.AddDate(0, 0, 1)
+	}
+
+	if !filter.To.IsZero() && filter.To.Before(filter.From) {
+		errStr := "from must not be after to"
+		return server.GetApiV1Export400JSONResponse{
+			BadRequestJSONResponse: server.BadRequestJSONResponse{Error: &errStr},
+		}, nil
+	}
+
+	logger := slog.With("component", "api")
+
+	// The export is written into a pipe while the response is being sent,
+	// so rows go straight from the database cursor to the client.
+	reader, writer := io.Pipe()
+	go func() {
+		count, err := a.exporter.Export(ctx, writer, format, filter)
+		if err != nil {
+			logger.ErrorContext(ctx, "Failed to export rates", slog.Int("count", count), slog.String("err", err.Error()))
+		}
+		writer.CloseWithError(err)
+	}()
+
+	switch format {
+	case exporter.FormatNDJSON:
+		return server.GetApiV1Export200ApplicationxNdjsonResponse{Body: reader}, nil
+	case exporter.FormatParquet:
+		return server.GetApiV1Export200ApplicationvndApacheParquetResponse{Body: reader}, nil
+	default:
+		return server.GetApiV1Export200TextcsvResponse{Body: reader}, nil
+	}
+}
+
 func mapRateToApiV1CurrencyHistoryRate(rate entity.Rate) server.Rate {
-	return server.Rate{
+	mapped := server.Rate{
+		Base:        rate.Base,
 		Code:        rate.Code,
 		Value:       rate.Value,
-		PublishedAt: rate.PublishedAt,
+		Type:        server.RateType(rate.Type),
+		Date:        openapi_types.Date{Time: rate.Date},
+		PublishedAt: rate.PublishedAt.UTC(),
+	}
+
+	if rate.Source != "" {
+		mapped.Source = &rate.Source
+	}
+
+	return mapped
+}
+
+// mapComputedRate maps a rate computed from the EUR rates instead of a stored one.
+func mapComputedRate(rate entity.Rate) server.Rate {
+	mapped := mapRateToApiV1CurrencyHistoryRate(rate)
+	computed := true
+	mapped.Computed = &computed
+	return mapped
+}
+
+func mapSourceRate(rate entity.SourceRate) server.SourceRate {
+	return server.SourceRate{
+		Source:      rate.Source,
+		Value:       rate.Rate.Value,
+		PublishedAt: rate.Rate.PublishedAt.UTC(),
+		Agrees:      rate.Agrees,
+	}
+}
+
+// requestedSeries reads the base and type query parameters of the currency, which default to
+// the ECB reference rate against the euro.
+func requestedSeries(currency string, base *server.Base, rateType *server.Type) (entity.Series, error) {
+	series := entity.ReferenceSeries(currency)
+	if base != nil && *base != "" {
+		series.Base = strings.ToUpper(*base)
+		if !mapper.IsCurrencyCode(series.Base) {
+			return entity.Series{}, fmt.Errorf("base %q is not a 3-letter currency code", *base)
+		}
 	}
+	if rateType != nil {
+		parsed, err := entity.ParseRateType(*rateType)
+		if err != nil {
+			return entity.Series{}, err
+		}
+		series.Type = parsed
+	}
+	return series, nil
+}
+
+func errToBadRequest(err error) server.BadRequestJSONResponse {
+	errStr := err.Error()
+	return server.BadRequestJSONResponse{Error: &errStr}
 }
 
 func errToInternalServerError(err error) server.InternalServerErrorJSONResponse {
@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zemzale/backscreen-home/domain/entity"
+	"github.com/zemzale/backscreen-home/domain/usecase/rebase"
+	"github.com/zemzale/backscreen-home/pkg/server"
+	"github.com/zemzale/backscreen-home/storage"
+)
+
+func TestRequestedSeries(t *testing.T) {
+	series, err := requestedSeries("USD", nil, nil)
+	if err != nil || series != entity.ReferenceSeries("USD") {
+		t.Errorf("Expected the EUR reference series by default, got %v (%v)", series, err)
+	}
+
+	base, rateType := server.Base("PLN"), server.Type("sell")
+	series, err = requestedSeries("USD", &base, &rateType)
+	if err != nil || series != (entity.Series{Base: "PLN", Code: "USD", Type: entity.RateTypeSell}) {
+		t.Errorf("Expected PLN/USD sell, got %v (%v)", series, err)
+	}
+
+	base = "pln"
+	series, err = requestedSeries("USD", &base, nil)
+	if err != nil || series.Base != "PLN" {
+		t.Errorf("Expected the base to be uppercased, got %v (%v)", series, err)
+	}
+
+	rateType = "mid"
+	if _, err := requestedSeries("USD", nil, &rateType); err == nil {
+		t.Error("Expected an unknown rate type to be rejected")
+	}
+
+	for _, base := range []server.Base{"EURO", "U1D"} {
+		if _, err := requestedSeries("USD", &base, nil); err == nil {
+			t.Errorf("Expected base %q to be rejected", base)
+		}
+	}
+}
+
+// fakeRates serves the rates of each series newest first. Like the storage client it has no
+// latest rate of an unknown series, but an empty history.
+type fakeRates map[entity.Series][]entity.Rate
+
+func (f fakeRates) GetLatestRate(_ context.Context, series entity.Series) (entity.Rate, error) {
+	if len(f[series]) == 0 {
+		return entity.Rate{}, storage.ErrNotFound
+	}
+	return f[series][0], nil
+}
+
+func (f fakeRates) GetRates(_ context.Context, series entity.Series) ([]entity.Rate, error) {
+	return f[series], nil
+}
+
+func testRate(base, code, value string) entity.Rate {
+	date := time.Date(2025, time.October, 10, 0, 0, 0, 0, time.UTC)
+	return entity.Rate{Base: base, Code: code, Value: value, Type: entity.RateTypeReference, Date: date, PublishedAt: date.Add(14 * time.Hour)}
+}
+
+func newRebaseAPI(rates fakeRates) api {
+	return api{rates: rates, rebase: rebase.New(rates)}
+}
+
+func TestRebaseFallback(t *testing.T) {
+	a := newRebaseAPI(fakeRates{
+		entity.ReferenceSeries("USD"): {testRate("EUR", "USD", "1.1568")},
+		entity.ReferenceSeries("GBP"): {testRate("EUR", "GBP", "0.87090")},
+	})
+	base := server.Base("gbp")
+
+	resp, err := a.GetApiV1Currency(context.Background(), server.GetApiV1CurrencyRequestObject{Currency: "USD", Params: server.GetApiV1CurrencyParams{Base: &base}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	rate, ok := resp.(server.GetApiV1Currency200JSONResponse)
+	if !ok {
+		t.Fatalf("Expected a 200 response, got %T", resp)
+	}
+	if rate.Base != "GBP" || rate.Value != "1.32828" || rate.Computed == nil || !*rate.Computed {
+		t.Errorf("Expected a computed GBP rate of 1.32828, got %+v", rate)
+	}
+
+	history, err := a.GetApiV1CurrencyHistory(context.Background(), server.GetApiV1CurrencyHistoryRequestObject{Currency: "USD", Params: server.GetApiV1CurrencyHistoryParams{Base: &base}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	rates, ok := history.(server.GetApiV1CurrencyHistory200JSONResponse)
+	if !ok {
+		t.Fatalf("Expected a 200 response, got %T", history)
+	}
+	if len(rates) != 1 || rates[0].Value != "1.32828" || rates[0].Computed == nil || !*rates[0].Computed {
+		t.Errorf("Expected the computed history, got %+v", rates)
+	}
+}
+
+func TestStoredRatesWin(t *testing.T) {
+	gbp := entity.Series{Base: "GBP", Code: "USD", Type: entity.RateTypeReference}
+	a := newRebaseAPI(fakeRates{
+		entity.ReferenceSeries("USD"): {testRate("EUR", "USD", "1.1568")},
+		entity.ReferenceSeries("GBP"): {testRate("EUR", "GBP", "0.87090")},
+		gbp:                           {testRate("GBP", "USD", "1.3300")},
+	})
+	base := server.Base("GBP")
+
+	resp, err := a.GetApiV1Currency(context.Background(), server.GetApiV1CurrencyRequestObject{Currency: "USD", Params: server.GetApiV1CurrencyParams{Base: &base}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	rate, ok := resp.(server.GetApiV1Currency200JSONResponse)
+	if !ok {
+		t.Fatalf("Expected a 200 response, got %T", resp)
+	}
+	if rate.Value != "1.3300" || rate.Computed != nil {
+		t.Errorf("Expected the stored rate without computed, got %+v", rate)
+	}
+
+	history, err := a.GetApiV1CurrencyHistory(context.Background(), server.GetApiV1CurrencyHistoryRequestObject{Currency: "USD", Params: server.GetApiV1CurrencyHistoryParams{Base: &base}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	rates, ok := history.(server.GetApiV1CurrencyHistory200JSONResponse)
+	if !ok {
+		t.Fatalf("Expected a 200 response, got %T", history)
+	}
+	if len(rates) != 1 || rates[0].Value != "1.3300" || rates[0].Computed != nil {
+		t.Errorf("Expected the stored history without computed, got %+v", rates)
+	}
+}
+
+func TestRebaseWithoutCommonDate(t *testing.T) {
+	a := newRebaseAPI(fakeRates{
+		entity.ReferenceSeries("USD"): {testRate("EUR", "USD", "1.1568")},
+	})
+	base := server.Base("JPY")
+
+	resp, err := a.GetApiV1Currency(context.Background(), server.GetApiV1CurrencyRequestObject{Currency: "USD", Params: server.GetApiV1CurrencyParams{Base: &base}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, ok := resp.(server.GetApiV1Currency404Response); !ok {
+		t.Errorf("Expected a 404 response, got %T", resp)
+	}
+
+	history, err := a.GetApiV1CurrencyHistory(context.Background(), server.GetApiV1CurrencyHistoryRequestObject{Currency: "USD", Params: server.GetApiV1CurrencyHistoryParams{Base: &base}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, ok := history.(server.GetApiV1Currency404Response); !ok {
+		t.Errorf("Expected a 404 response, got %T", history)
+	}
+}
+
+func TestExportRejectsUnknownFormat(t *testing.T) {
+	format := server.GetApiV1ExportParamsFormat("bogus")
+	resp, err := api{}.GetApiV1Export(context.Background(), server.GetApiV1ExportRequestObject{
+		Params: server.GetApiV1ExportParams{Format: &format},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, ok := resp.(server.GetApiV1Export400JSONResponse); !ok {
+		t.Errorf("Expected a 400 response, got %T", resp)
+	}
+}
+
+func TestAdminQuarantineRejectsUnknownStatus(t *testing.T) {
+	status := server.GetApiV1AdminQuarantineParamsStatus("aproved")
+	resp, err := api{}.GetApiV1AdminQuarantine(context.Background(), server.GetApiV1AdminQuarantineRequestObject{
+		Params: server.GetApiV1AdminQuarantineParams{Status: &status},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, ok := resp.(server.GetApiV1AdminQuarantine400JSONResponse); !ok {
+		t.Errorf("Expected a 400 response, got %T", resp)
+	}
+}
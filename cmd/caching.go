@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zemzale/backscreen-home/domain/ecb"
+	"github.com/zemzale/backscreen-home/pkg/server"
+)
+
+// cacheMiddleware adds validators and freshness headers to the rate responses. Rates for a publication
+// date never change, so the newest publication date identifies the response and it stays fresh until
+// the next ECB publication is expected. Responses that are behind the latest publication, because it
+// hasn't been synced yet, are only fresh for staleMaxAge. It must run inside negotiateMiddleware, which
+// picks the media type.
+func cacheMiddleware(staleMaxAge time.Duration) server.StrictMiddlewareFunc {
+	return func(f server.StrictHandlerFunc, operationID string) server.StrictHandlerFunc {
+		return func(ctx context.Context, w http.ResponseWriter, r *http.Request, request any) (any, error) {
+			response, err := f(ctx, w, r, request)
+			if err != nil {
+				return response, err
+			}
+
+			var (
+				rates       []server.Rate
+				notModified any
+			)
+			switch resp := response.(type) {
+			case server.GetApiV1Currency200JSONResponse:
+				rates = []server.Rate{server.Rate(resp)}
+				notModified = server.GetApiV1Currency304Response{}
+			case server.GetApiV1CurrencyHistory200JSONResponse:
+				rates = resp
+				notModified = server.GetApiV1CurrencyHistory304Response{}
+			default:
+				return response, nil
+			}
+
+			var newest, newestDate time.Time
+			for _, rate := range rates {
+				if rate.PublishedAt.After(newest) {
+					newest = rate.PublishedAt
+				}
+				if rate.Date.After(newestDate) {
+					newestDate = rate.Date.Time
+				}
+			}
+			// HTTP dates only have a precision of seconds.
+			lastModified := newest.UTC().Truncate(time.Second)
+
+			mediaType, _ := ctx.Value(mediaTypeContextKey{}).(string)
+			etag := fmt.Sprintf(`W/"%s-%d-%d-%s"`, operationID, lastModified.Unix(), len(rates), strings.ReplaceAll(mediaType, "/", "."))
+
+			maxAge := int(freshFor(newestDate, time.Now(), staleMaxAge).Seconds())
+
+			header := w.Header()
+			header.Set("ETag", etag)
+			header.Set("Last-Modified", lastModified.Format(http.TimeFormat))
+			header.Set("Cache-Control", "private, max-age="+strconv.Itoa(maxAge))
+			header.Add("Vary", "Accept")
+
+			if isNotModified(r, etag, lastModified) {
+				return notModified, nil
+			}
+
+			return response, nil
+		}
+	}
+}
+
+// freshFor returns how long a response with rates up to newestDate stays fresh. Up to date rates
+// don't change before the next publication, but rates that are behind the latest publication can
+// be replaced by the next sync at any time.
+func freshFor(newestDate, now time.Time, staleMaxAge time.Duration) time.Duration {
+	untilNext := ecb.NextPublication(now).Sub(now)
+	if !newestDate.Before(ecb.ReferenceDate(ecb.LatestPublication(now))) {
+		return untilNext
+	}
+
+	return min(staleMaxAge, untilNext)
+}
+
+func isNotModified(r *http.Request, etag string, lastModified time.Time) bool {
+	// If-None-Match takes precedence over If-Modified-Since when both are sent.
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		for candidate := range strings.SplitSeq(inm, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || weakETag(candidate) == weakETag(etag) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if ims := r.Header.Get("If-Modified-Since"); ims != "" {
+		since, err := http.ParseTime(ims)
+		if err != nil {
+			return false
+		}
+		return !lastModified.After(since)
+	}
+
+	return false
+}
+
+// weakETag strips the weak validator prefix, since If-None-Match uses the weak comparison.
+func weakETag(etag string) string {
+	return strings.TrimPrefix(etag, "W/")
+}
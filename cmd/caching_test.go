@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/zemzale/backscreen-home/domain/ecb"
+)
+
+func TestIsNotModified(t *testing.T) {
+	etag := `W/"GetApiV1Currency-1760054400-1-application.json"`
+	lastModified := time.Date(2025, time.October, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    bool
+	}{
+		{name: "no conditional headers", want: false},
+		{name: "matching etag", headers: map[string]string{"If-None-Match": `"other", ` + etag}, want: true},
+		{name: "strong form of weak etag", headers: map[string]string{"If-None-Match": `"GetApiV1Currency-1760054400-1-application.json"`}, want: true},
+		{name: "different etag", headers: map[string]string{"If-None-Match": `"other"`}, want: false},
+		{name: "wildcard", headers: map[string]string{"If-None-Match": "*"}, want: true},
+		{name: "not modified since", headers: map[string]string{"If-Modified-Since": lastModified.Format(http.TimeFormat)}, want: true},
+		{name: "modified since", headers: map[string]string{"If-Modified-Since": lastModified.Add(-time.Hour).Format(http.TimeFormat)}, want: false},
+		{
+			name: "etag takes precedence",
+			headers: map[string]string{
+				"If-None-Match":     `"other"`,
+				"If-Modified-Since": lastModified.Format(http.TimeFormat),
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+
+			if got := isNotModified(req, etag, lastModified); got != tt.want {
+				t.Errorf("Expected %t, got %t", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFreshFor(t *testing.T) {
+	friday := time.Date(2025, time.October, 10, 0, 0, 0, 0, time.UTC)
+	thursday := friday.AddDate(0, 0, -1)
+
+	tests := []struct {
+		name   string
+		newest time.Time
+		now    time.Time
+		want   time.Duration
+	}{
+		{
+			name:   "up to date before the publication",
+			newest: thursday,
+			now:    time.Date(2025, time.October, 10, 15, 0, 0, 0, ecb.Location),
+			want:   time.Hour,
+		},
+		{
+			name:   "published but not synced yet",
+			newest: thursday,
+			now:    time.Date(2025, time.October, 10, 16, 30, 0, 0, ecb.Location),
+			want:   5 * time.Minute,
+		},
+		{
+			name:   "synced after the publication",
+			newest: friday,
+			now:    time.Date(2025, time.October, 10, 16, 30, 0, 0, ecb.Location),
+			// Until Monday 16:00.
+			want: 71*time.Hour + 30*time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := freshFor(tt.newest, tt.now, 5*time.Minute); got != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
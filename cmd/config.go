@@ -0,0 +1,312 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cast"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/zemzale/backscreen-home/domain/anomaly"
+	"github.com/zemzale/backscreen-home/pkg/logging"
+	"github.com/zemzale/backscreen-home/pkg/middleware"
+	"github.com/zemzale/backscreen-home/pkg/telemetry"
+	"go.yaml.in/yaml/v3"
+)
+
+const envPrefix = "BACKSCREEN"
+
+// redacted replaces secret values when the config is printed.
+const redacted = "[REDACTED]"
+
+// secretKeyParts mark config keys whose values must never be printed.
+var secretKeyParts = []string{"password", "secret", "token"}
+
+// fileKeySuffix marks a key holding the path of a file with the value of another key,
+// e.g. database.password_file for Docker and Kubernetes secrets.
+const fileKeySuffix = "_file"
+
+// fileKeys can be read from a file instead.
+var fileKeys = []string{"database.user", "database.password"}
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the configuration",
+	// The config commands must work without a database and with an invalid config,
+	// so the root setup is skipped.
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return readConfig(cmd)
+	},
+}
+
+var configPrintCmd = &cobra.Command{
+	Use:   "print",
+	Short: "Print the effective configuration",
+	Long:  `Print the configuration after merging defaults, the config file, environment variables and flags. Secrets are redacted.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out, err := yaml.Marshal(printableSettings(viper.GetViper()))
+		if err != nil {
+			return fmt.Errorf("failed to encode config: %w", err)
+		}
+
+		if file := viper.ConfigFileUsed(); file != "" {
+			fmt.Fprintf(cmd.OutOrStdout(), "# config file: %s\n", file)
+		}
+		if _, err := cmd.OutOrStdout().Write(out); err != nil {
+			return err
+		}
+
+		return validateConfig(viper.GetViper())
+	},
+}
+
+// loadConfig reads the config file and validates the merged configuration.
+func loadConfig(cmd *cobra.Command) error {
+	if err := readConfig(cmd); err != nil {
+		return err
+	}
+	return validateConfig(viper.GetViper())
+}
+
+// readConfig reads the config file. Precedence from highest to lowest is flags,
+// environment, config file and defaults.
+func readConfig(cmd *cobra.Command) error {
+	// Flags are parsed by now, errors from here on are not about usage.
+	cmd.SilenceUsage = true
+
+	bindEnv(viper.GetViper())
+
+	path, _ := cmd.Flags().GetString("config")
+	if path == "" {
+		path = os.Getenv(envPrefix + "_CONFIG")
+	}
+
+	if path != "" {
+		viper.SetConfigFile(path)
+	} else {
+		viper.SetConfigName("backscreen")
+		viper.AddConfigPath(".")
+		viper.AddConfigPath("/etc/backscreen-home")
+	}
+
+	if err := viper.ReadInConfig(); err != nil {
+		// Without an explicit path the config file is optional.
+		var notFound viper.ConfigFileNotFoundError
+		if path != "" || !errors.As(err, &notFound) {
+			return fmt.Errorf("failed to read config file: %w", err)
+		}
+	}
+
+	return readFileKeys(viper.GetViper())
+}
+
+// readFileKeys sets the keys that have a file configured to the contents of the file.
+// The file takes precedence over every other source of the key.
+func readFileKeys(v *viper.Viper) error {
+	for _, key := range fileKeys {
+		path := v.GetString(key + fileKeySuffix)
+		if path == "" {
+			continue
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s%s: %w", key, fileKeySuffix, err)
+		}
+
+		// Secret files are often written with a trailing newline that isn't part of the value.
+		v.Set(key, strings.TrimRight(string(content), "\r\n"))
+	}
+
+	return nil
+}
+
+// bindEnv binds every known key to BACKSCREEN_<KEY> with dots replaced by underscores.
+// The dotted names like BACKSCREEN_DATABASE.HOST keep working for existing deployments,
+// but lose to the new ones. Keys must have a default or a flag to be known.
+//
+// AutomaticEnv and an env key replacer are not used, viper would look up the dotted name
+// first and apply the replacer to it as well.
+func bindEnv(v *viper.Viper) {
+	for _, key := range v.AllKeys() {
+		_ = v.BindEnv(key, envName(key), envPrefix+"_"+strings.ToUpper(key))
+	}
+}
+
+func envName(key string) string {
+	return envPrefix + "_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// validateConfig reports every invalid setting at once, so a broken deployment can be fixed in one go.
+func validateConfig(v *viper.Viper) error {
+	var errs []error
+	invalid := func(key, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("invalid %s (%s): %s", key, envName(key), fmt.Sprintf(format, args...)))
+	}
+
+	if _, err := logging.ParseLevel(v.GetString("log.level")); err != nil {
+		invalid("log.level", "%v", err)
+	}
+	if format := v.GetString("log.format"); format != logging.FormatText && format != logging.FormatJSON {
+		invalid("log.format", "%q must be text or json", format)
+	}
+	if _, err := logging.ParseComponentLevels(v.GetString("log.components")); err != nil {
+		invalid("log.components", "%v", err)
+	}
+
+	if port, err := cast.ToIntE(v.Get("database.port")); err != nil || port < 1 || port > 65535 {
+		invalid("database.port", "%q must be a port between 1 and 65535", v.GetString("database.port"))
+	}
+
+	if (v.GetString("database.tls.cert") == "") != (v.GetString("database.tls.key") == "") {
+		invalid("database.tls.cert", "the client certificate and database.tls.key must be set together")
+	}
+
+	exporters := []string{telemetry.ExporterNone, telemetry.ExporterOTLP, telemetry.ExporterStdout, telemetry.ExporterFile}
+	if exporter := v.GetString("telemetry.exporter"); !slices.Contains(exporters, exporter) {
+		invalid("telemetry.exporter", "%q must be one of %s", exporter, strings.Join(exporters, ", "))
+	}
+	if ratio, err := cast.ToFloat64E(v.Get("telemetry.sample_ratio")); err != nil || ratio < 0 || ratio > 1 {
+		invalid("telemetry.sample_ratio", "%q must be a number between 0 and 1", v.GetString("telemetry.sample_ratio"))
+	}
+
+	if strings.TrimSpace(v.GetString("api.host")) == "" {
+		invalid("api.host", "must be set")
+	}
+	if _, err := middleware.ParseLimit(v.GetString("api.ratelimit.default")); err != nil {
+		invalid("api.ratelimit.default", "%v", err)
+	}
+	if _, err := middleware.ParseRouteLimits(v.GetString("api.ratelimit.routes")); err != nil {
+		invalid("api.ratelimit.routes", "%v", err)
+	}
+	if _, err := middleware.ParseLimit(v.GetString("api.ratelimit.ip")); err != nil {
+		invalid("api.ratelimit.ip", "%v", err)
+	}
+	if size, err := cast.ToIntE(v.Get("api.cache.size")); err != nil || size < 1 {
+		invalid("api.cache.size", "%q must be a positive number", v.GetString("api.cache.size"))
+	}
+
+	if change, err := cast.ToFloat64E(v.Get("sync.validation.max_change")); err != nil || change < 0 {
+		invalid("sync.validation.max_change", "%q must be zero or a positive fraction like 0.1", v.GetString("sync.validation.max_change"))
+	}
+	if _, err := anomaly.ParseMaxChanges(v.GetString("sync.validation.max_changes")); err != nil {
+		invalid("sync.validation.max_changes", "%v", err)
+	}
+	if _, err := anomaly.ParsePegs(v.GetString("sync.validation.pegs")); err != nil {
+		invalid("sync.validation.pegs", "%v", err)
+	}
+	if _, err := parseSources(v.GetString("sync.sources")); err != nil {
+		invalid("sync.sources", "%v", err)
+	}
+	if s := v.GetString("sync.fallback.default"); strings.TrimSpace(s) != "" {
+		if _, err := parseSources(s); err != nil {
+			invalid("sync.fallback.default", "%v", err)
+		}
+	}
+	if _, err := parseFallbacks(v.GetString("sync.fallback.currencies")); err != nil {
+		invalid("sync.fallback.currencies", "%v", err)
+	}
+	if tolerance, err := cast.ToFloat64E(v.Get("sync.reconcile.tolerance")); err != nil || tolerance < 0 {
+		invalid("sync.reconcile.tolerance", "%q must be zero or a positive fraction like 0.0001", v.GetString("sync.reconcile.tolerance"))
+	}
+
+	for _, key := range []string{"database.pool.max_open_conns", "database.pool.max_idle_conns"} {
+		if n, err := cast.ToIntE(v.Get(key)); err != nil || n < 0 {
+			invalid(key, "%q must be zero or a positive number", v.GetString(key))
+		}
+	}
+
+	durations := map[string]bool{
+		"database.pool.conn_max_lifetime":  false,
+		"database.pool.conn_max_idle_time": false,
+		"database.pool.check_interval":     false,
+		"database.connect.timeout":         false,
+		"database.connect.initial_backoff": true,
+		"database.connect.max_backoff":     true,
+		"api.cache.ttl":                    true,
+		"api.cache.poll_interval":          true,
+		"api.cache.stale_max_age":          false,
+		"api.status.grace":                 false,
+		"api.readiness.max_data_age":       false,
+		"sync.interval":                    false,
+	}
+	for key, positive := range durations {
+		d, err := cast.ToDurationE(v.Get(key))
+		switch {
+		case err != nil:
+			invalid(key, "%q is not a duration like 30s or 2h", v.GetString(key))
+		case positive && d <= 0:
+			invalid(key, "must be greater than zero")
+		case d < 0:
+			invalid(key, "must not be negative")
+		}
+	}
+
+	slices.SortFunc(errs, func(a, b error) int { return strings.Compare(a.Error(), b.Error()) })
+	return errors.Join(errs...)
+}
+
+// validateDatabaseConfig checks the settings that are only required by commands using the database.
+func validateDatabaseConfig(v *viper.Viper) error {
+	var errs []error
+	for _, key := range []string{"database.host", "database.user", "database.database"} {
+		if strings.TrimSpace(v.GetString(key)) == "" {
+			errs = append(errs, fmt.Errorf("invalid %s (%s): must be set", key, envName(key)))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// printableSettings returns the settings with secrets redacted and durations in their readable form.
+func printableSettings(v *viper.Viper) map[string]any {
+	return printable("", v.AllSettings())
+}
+
+func printable(prefix string, settings map[string]any) map[string]any {
+	out := make(map[string]any, len(settings))
+
+	for key, value := range settings {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+
+		switch v := value.(type) {
+		case map[string]any:
+			out[key] = printable(path, v)
+		case time.Duration:
+			out[key] = v.String()
+		default:
+			out[key] = value
+		}
+
+		if isSecretKey(path) && cast.ToString(value) != "" {
+			out[key] = redacted
+		}
+	}
+
+	return out
+}
+
+func isSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	// The path of a secret file is not a secret.
+	if strings.HasSuffix(key, fileKeySuffix) {
+		return false
+	}
+
+	for _, part := range secretKeyParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	configCmd.AddCommand(configPrintCmd)
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// validViper starts from the defaults and flag defaults the commands register on the global
+// viper, so it can't drift from them. A database user is the only setting without a default.
+func validViper() *viper.Viper {
+	v := viper.New()
+	for _, key := range viper.AllKeys() {
+		v.SetDefault(key, viper.Get(key))
+	}
+	v.SetDefault("database.user", "root")
+	return v
+}
+
+func TestValidateConfig(t *testing.T) {
+	if err := validateConfig(validViper()); err != nil {
+		t.Fatalf("Expected defaults to be valid, got %v", err)
+	}
+
+	v := validViper()
+	v.Set("database.user", "")
+	v.Set("database.port", "abc")
+	v.Set("log.format", "xml")
+	v.Set("api.cache.ttl", "soon")
+	v.Set("sync.validation.pegs", "BGN=1.95583")
+	v.Set("sync.sources", "lvbank,fed")
+	v.Set("sync.fallback.currencies", "GBP=ecb|ecb")
+
+	err := validateConfig(v)
+	if err == nil {
+		t.Fatal("Expected an error, got nil")
+	}
+
+	for _, want := range []string{
+		"invalid database.port (BACKSCREEN_DATABASE_PORT)",
+		"invalid log.format (BACKSCREEN_LOG_FORMAT)",
+		"invalid api.cache.ttl (BACKSCREEN_API_CACHE_TTL)",
+		"invalid sync.validation.pegs (BACKSCREEN_SYNC_VALIDATION_PEGS)",
+		"invalid sync.sources (BACKSCREEN_SYNC_SOURCES)",
+		"invalid sync.fallback.currencies (BACKSCREEN_SYNC_FALLBACK_CURRENCIES)",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error to contain %q, got %q", want, err.Error())
+		}
+	}
+}
+
+func TestValidateDatabaseConfig(t *testing.T) {
+	v := validViper()
+	if err := validateDatabaseConfig(v); err != nil {
+		t.Fatalf("Expected defaults to be valid, got %v", err)
+	}
+
+	v.Set("database.user", "")
+	err := validateDatabaseConfig(v)
+	if err == nil || !strings.Contains(err.Error(), "invalid database.user (BACKSCREEN_DATABASE_USER)") {
+		t.Errorf("Expected a missing user error, got %v", err)
+	}
+}
+
+func TestBindEnv(t *testing.T) {
+	t.Setenv("BACKSCREEN_DATABASE.HOST", "legacy")
+	t.Setenv("BACKSCREEN_DATABASE.USER", "legacy")
+	t.Setenv("BACKSCREEN_DATABASE_USER", "new")
+
+	v := validViper()
+	bindEnv(v)
+
+	if got := v.GetString("database.host"); got != "legacy" {
+		t.Errorf("Expected host from the dotted variable, got %q", got)
+	}
+	if got := v.GetString("database.user"); got != "new" {
+		t.Errorf("Expected user from the underscored variable, got %q", got)
+	}
+}
+
+func TestPrintableSettings(t *testing.T) {
+	v := validViper()
+	v.Set("database.password", "hunter2")
+
+	settings := printableSettings(v)
+	database := settings["database"].(map[string]any)
+
+	if got := database["password"]; got != redacted {
+		t.Errorf("Expected password to be redacted, got %v", got)
+	}
+	if got := database["user"]; got != "root" {
+		t.Errorf("Expected user root, got %v", got)
+	}
+	if got := settings["api"].(map[string]any)["cache"].(map[string]any)["ttl"]; got != "10m0s" {
+		t.Errorf("Expected ttl 10m0s, got %v", got)
+	}
+}
+
+func TestReadFileKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "password")
+	if err := os.WriteFile(path, []byte("hunter2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	v := validViper()
+	v.Set("database.password", "from-env")
+	v.Set("database.password_file", path)
+
+	if err := readFileKeys(v); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := v.GetString("database.password"); got != "hunter2" {
+		t.Errorf("Expected password from the file, got %q", got)
+	}
+
+	if got := printableSettings(v)["database"].(map[string]any)["password_file"]; got != path {
+		t.Errorf("Expected the file path not to be redacted, got %v", got)
+	}
+
+	v.Set("database.password_file", filepath.Join(t.TempDir(), "missing"))
+	if err := readFileKeys(v); err == nil {
+		t.Error("Expected an error for a missing file, got nil")
+	}
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zemzale/backscreen-home/domain/entity"
+	"github.com/zemzale/backscreen-home/domain/usecase/exporter"
+	"github.com/zemzale/backscreen-home/storage"
+)
+
+const exportDateLayout = time.DateOnly
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export stored currency exchange rates",
+	Long:  `Export stored currency exchange rates as CSV, NDJSON or Parquet.`,
+	RunE: withStore(func(cmd *cobra.Command, args []string, store *storage.Client) error {
+		ctx := cmd.Context()
+
+		logger := slog.With("component", "export")
+
+		flags := cmd.Flags()
+		currencies, _ := flags.GetStringSlice("currency")
+		bases, _ := flags.GetStringSlice("base")
+		typeStrs, _ := flags.GetStringSlice("type")
+		fromStr, _ := flags.GetString("from")
+		toStr, _ := flags.GetString("to")
+		formatStr, _ := flags.GetString("format")
+		output, _ := flags.GetString("output")
+
+		format, err := exporter.ParseFormat(formatStr)
+		if err != nil {
+			return err
+		}
+
+		filter := storage.RateFilter{Bases: bases, Codes: currencies}
+		for _, s := range typeStrs {
+			rateType, err := entity.ParseRateType(s)
+			if err != nil {
+				return fmt.Errorf("invalid --type: %w", err)
+			}
+			filter.Types = append(filter.Types, rateType)
+		}
+		if fromStr != "" {
+			filter.From, err = time.Parse(exportDateLayout, fromStr)
+			if err != nil {
+				return fmt.Errorf("invalid --from date: %w", err)
+			}
+		}
+		if toStr != "" {
+			to, err := time.Parse(exportDateLayout, toStr)
+			if err != nil {
+				return fmt.Errorf("invalid --to date: %w", err)
+			}
+			// The range is inclusive for the caller, but the filter upper bound is exclusive.
+			filter.To = to.AddDate(0, 0, 1)
+		}
+
+		var w io.Writer = cmd.OutOrStdout()
+		if output != "" && output != "-" {
+			file, err := os.Create(output)
+			if err != nil {
+				return fmt.Errorf("failed to create output file: %w", err)
+			}
+			defer file.Close()
+			w = file
+		}
+
+		logger.InfoContext(ctx, "Starting export", slog.String("format", string(format)), slog.String("output", output))
+
+		count, err := exporter.New(store).Export(ctx, w, format, filter)
+		if err != nil {
+			return err
+		}
+
+		logger.InfoContext(ctx, "Finished export", slog.Int("count", count))
+		return nil
+	}),
+}
+
+func init() {
+	flags := exportCmd.Flags()
+	flags.StringSlice("currency", nil, "currency codes to export, all when empty")
+	flags.StringSlice("base", nil, "base currencies to export, all when empty")
+	flags.StringSlice("type", nil, "rate types to export (reference, buy or sell), all when empty")
+	flags.String("from", "", "inclusive start date (YYYY-MM-DD)")
+	flags.String("to", "", "inclusive end date (YYYY-MM-DD)")
+	flags.String("format", string(exporter.FormatCSV), "export format: csv, ndjson or parquet")
+	flags.StringP("output", "o", "", "output file, stdout when empty")
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/zemzale/backscreen-home/domain/usecase/status"
+	"github.com/zemzale/backscreen-home/storage"
+)
+
+// health serves the orchestration endpoints. They live outside of the OpenAPI spec,
+// so they are not behind authentication or rate limiting.
+type health struct {
+	store  *storage.Client
+	status *status.Usecase
+	// maxDataAge makes readiness fail when the stored rates are older, zero disables the check.
+	maxDataAge time.Duration
+}
+
+func (h health) Mount(r chi.Router) {
+	r.Get("/healthz", h.healthz)
+	r.Get("/readyz", h.readyz)
+	r.Get("/status", h.statusReport)
+}
+
+func (h health) healthz(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func (h health) readyz(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := slog.With("component", "health")
+
+	pingCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	if err := h.store.Ping(pingCtx); err != nil {
+		logger.WarnContext(ctx, "Readiness check failed, database unreachable", slog.Any("error", err))
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"status": "unavailable", "reason": "database unreachable"})
+		return
+	}
+
+	if h.maxDataAge > 0 {
+		report, err := h.status.Check(ctx, allowedCurrencies, time.Now())
+		if err != nil {
+			logger.WarnContext(ctx, "Readiness check failed", slog.Any("error", err))
+			writeJSON(w, http.StatusServiceUnavailable, map[string]string{"status": "unavailable", "reason": "failed to check data age"})
+			return
+		}
+
+		if report.MaxAge() > h.maxDataAge {
+			logger.WarnContext(ctx, "Readiness check failed, data too old", slog.Duration("max_data_age", h.maxDataAge))
+			writeJSON(w, http.StatusServiceUnavailable, map[string]string{"status": "unavailable", "reason": "data older than " + h.maxDataAge.String()})
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+type statusResponse struct {
+	Status       string                   `json:"status"`
+	CheckedAt    time.Time                `json:"checked_at"`
+	ExpectedDate string                   `json:"expected_date"`
+	Currencies   []currencyStatusResponse `json:"currencies"`
+}
+
+type currencyStatusResponse struct {
+	Code              string     `json:"code"`
+	LatestDate        *string    `json:"latest_date"`
+	LatestPublishedAt *time.Time `json:"latest_published_at"`
+	Stale             bool       `json:"stale"`
+}
+
+func (h health) statusReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	report, err := h.status.Check(ctx, allowedCurrencies, time.Now())
+	if err != nil {
+		slog.With("component", "health").ErrorContext(ctx, "Failed to check status", slog.Any("error", err))
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return
+	}
+
+	resp := statusResponse{
+		Status:       "ok",
+		CheckedAt:    report.CheckedAt,
+		ExpectedDate: report.ExpectedDate.Format(time.DateOnly),
+		Currencies:   make([]currencyStatusResponse, 0, len(report.Currencies)),
+	}
+	if report.Stale() {
+		resp.Status = "stale"
+	}
+
+	for _, c := range report.Currencies {
+		item := currencyStatusResponse{Code: c.Code, Stale: c.Stale}
+		if !c.LatestDate.IsZero() {
+			latestDate := c.LatestDate.Format(time.DateOnly)
+			item.LatestDate = &latestDate
+			item.LatestPublishedAt = &c.LatestPublishedAt
+		}
+		resp.Currencies = append(resp.Currencies, item)
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/zemzale/backscreen-home/domain/entity"
+	"github.com/zemzale/backscreen-home/domain/mapper"
+	"github.com/zemzale/backscreen-home/storage"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Import rates from a feed file",
+	Long: `Import rates from a saved feed or a history file in the same format. The file is streamed
+and stored in batches, so multi-year files don't have to fit in memory. Reads stdin when no file or - is given.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: withStore(func(cmd *cobra.Command, args []string, store *storage.Client) error {
+		ctx := cmd.Context()
+		logger := slog.With("component", "import")
+
+		flags := cmd.Flags()
+		currencies, _ := flags.GetStringSlice("currency")
+		batchSize, _ := flags.GetInt("batch-size")
+		lenient, _ := flags.GetBool("lenient")
+		source, _ := flags.GetString("source")
+
+		if batchSize < 1 {
+			return fmt.Errorf("invalid --batch-size %d, must be positive", batchSize)
+		}
+
+		var r io.Reader = cmd.InOrStdin()
+		name := "stdin"
+		if len(args) == 1 && args[0] != "-" {
+			file, err := os.Open(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to open feed: %w", err)
+			}
+			defer file.Close()
+			r, name = file, args[0]
+		}
+
+		logger.InfoContext(ctx, "Starting import", slog.String("input", name), slog.Int("batch_size", batchSize))
+
+		result, err := importRates(ctx, r, store, importOptions{
+			name:       name,
+			currencies: currencies,
+			batchSize:  batchSize,
+			lenient:    lenient,
+			source:     source,
+		})
+		if err != nil {
+			return err
+		}
+
+		logger.InfoContext(ctx, "Finished import",
+			slog.Int("read", result.read),
+			slog.Int("inserted", result.inserted),
+			slog.Int("duplicates", result.read-result.inserted),
+			slog.Int("skipped_items", result.skippedItems),
+		)
+
+		fmt.Fprintf(cmd.OutOrStdout(), "Imported %d of %d rates, %d were already stored\n", result.inserted, result.read, result.read-result.inserted)
+		return nil
+	}),
+}
+
+// rateStorer is implemented by the storage client.
+type rateStorer interface {
+	StoreRates(ctx context.Context, rates []entity.Rate) (int, error)
+}
+
+type importOptions struct {
+	// name identifies the input in errors.
+	name       string
+	currencies []string
+	batchSize  int
+	lenient    bool
+	source     string
+}
+
+type importResult struct {
+	read, inserted, skippedItems int
+}
+
+// importRates streams the rates of the feed in r into the store in batches.
+func importRates(ctx context.Context, r io.Reader, store rateStorer, opts importOptions) (importResult, error) {
+	logger := slog.With("component", "import")
+
+	wanted := make(map[string]bool, len(opts.currencies))
+	for _, code := range opts.currencies {
+		wanted[strings.ToUpper(code)] = true
+	}
+
+	var (
+		batch  = make([]entity.Rate, 0, opts.batchSize)
+		result importResult
+	)
+
+	flush := func() error {
+		n, err := store.StoreRates(ctx, batch)
+		if err != nil {
+			return fmt.Errorf("failed to store rates: %w", err)
+		}
+		result.inserted += n
+		batch = batch[:0]
+
+		logger.DebugContext(ctx, "Stored batch", slog.Int("read", result.read), slog.Int("inserted", result.inserted))
+		return nil
+	}
+
+	for rate, err := range mapper.StreamRates(r) {
+		var itemErr *mapper.ParseError
+		if opts.lenient && errors.As(err, &itemErr) {
+			logger.WarnContext(ctx, "Skipped malformed feed item", slog.Any("error", itemErr))
+			result.skippedItems++
+			continue
+		}
+		if err != nil {
+			// Batches stored so far are kept, importing again skips them as duplicates.
+			return result, describeParseError(opts.name, err)
+		}
+
+		if len(wanted) > 0 && !wanted[rate.Code] {
+			continue
+		}
+
+		result.read++
+		rate.Source = opts.source
+		batch = append(batch, rate)
+		if len(batch) == opts.batchSize {
+			if err := flush(); err != nil {
+				return result, err
+			}
+		}
+	}
+
+	if len(batch) > 0 {
+		if err := flush(); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+func init() {
+	flags := importCmd.Flags()
+	flags.StringSlice("currency", nil, "only import these currency codes, all when empty")
+	flags.Int("batch-size", 500, "rates stored per batch, batches over the MySQL placeholder limit are split")
+	flags.Bool("lenient", false, "skip malformed feed items instead of stopping the import")
+	flags.String("source", "lvbank", "source recorded for the imported rates")
+}
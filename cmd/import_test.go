@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/zemzale/backscreen-home/domain/entity"
+)
+
+// fakeRateStore keeps the stored rates by currency and date and the size of every batch.
+type fakeRateStore struct {
+	stored  map[string]entity.Rate
+	batches []int
+}
+
+func (f *fakeRateStore) StoreRates(_ context.Context, rates []entity.Rate) (int, error) {
+	f.batches = append(f.batches, len(rates))
+
+	inserted := 0
+	for _, rate := range rates {
+		key := rate.Code + rate.Date.Format("2006-01-02")
+		if _, ok := f.stored[key]; ok {
+			continue
+		}
+		f.stored[key] = rate
+		inserted++
+	}
+	return inserted, nil
+}
+
+const importFeed = `<rss><channel>
+	<item>
+		<guid>https://www.bank.lv/#10.10</guid>
+		<description><![CDATA[AUD 1.76500000 BGN 1.95580000 USD 1.15680000 ]]></description>
+		<pubDate>Fri, 10 Oct 2025 03:00:00 +0300</pubDate>
+	</item>
+	<item>
+		<guid>broken</guid>
+		<description><![CDATA[AUD abc ]]></description>
+		<pubDate>Mon, 13 Oct 2025 03:00:00 +0300</pubDate>
+	</item>
+	<item>
+		<guid>https://www.bank.lv/#14.10</guid>
+		<description><![CDATA[AUD 1.77750000 BGN 1.95580000 USD 1.16110000 ]]></description>
+		<pubDate>Tue, 14 Oct 2025 03:00:00 +0300</pubDate>
+	</item>
+</channel></rss>`
+
+func TestImportRates(t *testing.T) {
+	store := &fakeRateStore{stored: map[string]entity.Rate{}}
+	opts := importOptions{name: "feed.xml", currencies: []string{"aud", "usd"}, batchSize: 3, lenient: true, source: "ecb"}
+
+	result, err := importRates(context.Background(), strings.NewReader(importFeed), store, opts)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result != (importResult{read: 4, inserted: 4, skippedItems: 1}) {
+		t.Errorf("Expected 4 rates read and inserted and 1 item skipped, got %+v", result)
+	}
+	if len(store.batches) != 2 || store.batches[0] != 3 || store.batches[1] != 1 {
+		t.Errorf("Expected batches of 3 and 1, got %v", store.batches)
+	}
+	for _, rate := range store.stored {
+		if rate.Source != "ecb" || rate.Code == "BGN" {
+			t.Errorf("Expected only AUD and USD from ecb, got %+v", rate)
+		}
+	}
+
+	// Importing again only finds duplicates.
+	result, err = importRates(context.Background(), strings.NewReader(importFeed), store, opts)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.inserted != 0 || result.read != 4 {
+		t.Errorf("Expected 4 duplicates, got %+v", result)
+	}
+
+	opts.lenient = false
+	_, err = importRates(context.Background(), strings.NewReader(importFeed), store, opts)
+	if err == nil || !strings.HasPrefix(err.Error(), "feed.xml: ") {
+		t.Errorf("Expected a parse error naming the input, got %v", err)
+	}
+}
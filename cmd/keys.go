@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zemzale/backscreen-home/domain/entity"
+	"github.com/zemzale/backscreen-home/domain/usecase/apikeys"
+	"github.com/zemzale/backscreen-home/slices"
+	"github.com/zemzale/backscreen-home/storage"
+)
+
+var keysCmd = &cobra.Command{
+	Use:   "keys",
+	Short: "Manage API keys",
+	Long:  `Create, list and revoke the API keys used to authenticate against the API.`,
+}
+
+var keysCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a new API key",
+	Long:  `Create a new API key. The key is printed only once, store it somewhere safe.`,
+	Args:  cobra.NoArgs,
+	RunE: withStore(func(cmd *cobra.Command, args []string, store *storage.Client) error {
+		ctx := cmd.Context()
+
+		name, _ := cmd.Flags().GetString("name")
+		scopeNames, _ := cmd.Flags().GetStringSlice("scope")
+
+		scopes := make([]entity.Scope, 0, len(scopeNames))
+		for _, s := range scopeNames {
+			scope, err := entity.ParseScope(s)
+			if err != nil {
+				return err
+			}
+			scopes = append(scopes, scope)
+		}
+
+		token, key, err := apikeys.New(store).Create(ctx, name, scopes)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(cmd.ErrOrStderr(), "Created key %d (%s) with scopes %s\n", key.ID, key.Name, formatScopes(key.Scopes))
+		fmt.Fprintln(cmd.OutOrStdout(), token)
+
+		return nil
+	}),
+}
+
+var keysListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List API keys",
+	Args:  cobra.NoArgs,
+	RunE: withStore(func(cmd *cobra.Command, args []string, store *storage.Client) error {
+		ctx := cmd.Context()
+
+		keys, err := apikeys.New(store).List(ctx)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tPREFIX\tSCOPES\tCREATED\tREVOKED")
+		for _, key := range keys {
+			revoked := "-"
+			if key.Revoked() {
+				revoked = key.RevokedAt.Format(time.RFC3339)
+			}
+
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n",
+				key.ID, key.Name, key.Prefix, formatScopes(key.Scopes), key.CreatedAt.Format(time.RFC3339), revoked,
+			)
+		}
+
+		return w.Flush()
+	}),
+}
+
+var keysRevokeCmd = &cobra.Command{
+	Use:   "revoke <id>",
+	Short: "Revoke an API key",
+	Args:  cobra.ExactArgs(1),
+	RunE: withStore(func(cmd *cobra.Command, args []string, store *storage.Client) error {
+		ctx := cmd.Context()
+
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid key id %q: %w", args[0], err)
+		}
+
+		return apikeys.New(store).Revoke(ctx, id)
+	}),
+}
+
+func formatScopes(scopes []entity.Scope) string {
+	return strings.Join(slices.Map(scopes, func(s entity.Scope) string { return string(s) }), ",")
+}
+
+func init() {
+	keysCreateCmd.Flags().String("name", "", "name describing who uses the key")
+	keysCreateCmd.Flags().StringSlice("scope", []string{string(entity.ScopeRead)}, "scopes granted to the key: read, export or admin")
+	_ = keysCreateCmd.MarkFlagRequired("name")
+
+	keysCmd.AddCommand(keysCreateCmd)
+	keysCmd.AddCommand(keysListCmd)
+	keysCmd.AddCommand(keysRevokeCmd)
+}
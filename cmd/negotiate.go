@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zemzale/backscreen-home/pkg/server"
+)
+
+const (
+	mediaTypeJSON = "application/json"
+	mediaTypeCSV  = "text/csv"
+	mediaTypeXML  = "application/xml"
+)
+
+// supportedMediaTypes are listed in order of preference, the first one is used
+// when the client accepts anything.
+var supportedMediaTypes = []string{mediaTypeJSON, mediaTypeCSV, mediaTypeXML}
+
+// mediaTypeContextKey holds the negotiated media type for the inner strict middlewares.
+type mediaTypeContextKey struct{}
+
+var formatMediaTypes = map[string]string{
+	"json": mediaTypeJSON,
+	"csv":  mediaTypeCSV,
+	"xml":  mediaTypeXML,
+}
+
+// negotiateMiddleware renders the JSON responses returned by the strict handlers as CSV or XML
+// when the client asks for it, so the handlers stay the single source of the data.
+func negotiateMiddleware(f server.StrictHandlerFunc, operationID string) server.StrictHandlerFunc {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request, request any) (any, error) {
+		var format string
+		switch req := request.(type) {
+		case server.GetApiV1CurrencyRequestObject:
+			if req.Params.Format != nil {
+				format = string(*req.Params.Format)
+			}
+		case server.GetApiV1CurrencyHistoryRequestObject:
+			if req.Params.Format != nil {
+				format = string(*req.Params.Format)
+			}
+		default:
+			return f(ctx, w, r, request)
+		}
+
+		mediaType, ok := formatMediaTypes[format]
+		if !ok && format != "" {
+			err := fmt.Errorf("unsupported format %q", format)
+			switch operationID {
+			case "GetApiV1CurrencyHistory":
+				return server.GetApiV1CurrencyHistory400JSONResponse{BadRequestJSONResponse: errToBadRequest(err)}, nil
+			default:
+				return server.GetApiV1Currency400JSONResponse{BadRequestJSONResponse: errToBadRequest(err)}, nil
+			}
+		}
+		if !ok {
+			mediaType, ok = negotiateMediaType(r.Header.Get("Accept"))
+		}
+		if !ok {
+			switch operationID {
+			case "GetApiV1CurrencyHistory":
+				return server.GetApiV1CurrencyHistory406Response{}, nil
+			default:
+				return server.GetApiV1Currency406Response{}, nil
+			}
+		}
+
+		response, err := f(context.WithValue(ctx, mediaTypeContextKey{}, mediaType), w, r, request)
+		if err != nil || mediaType == mediaTypeJSON {
+			return response, err
+		}
+
+		switch resp := response.(type) {
+		case server.GetApiV1Currency200JSONResponse:
+			body, err := encodeRates(mediaType, []server.Rate{server.Rate(resp)}, false)
+			if err != nil {
+				return nil, err
+			}
+			if mediaType == mediaTypeCSV {
+				return server.GetApiV1Currency200TextcsvResponse{Body: body, ContentLength: int64(body.Len())}, nil
+			}
+			return server.GetApiV1Currency200ApplicationxmlResponse{Body: body, ContentLength: int64(body.Len())}, nil
+		case server.GetApiV1CurrencyHistory200JSONResponse:
+			body, err := encodeRates(mediaType, resp, true)
+			if err != nil {
+				return nil, err
+			}
+			if mediaType == mediaTypeCSV {
+				return server.GetApiV1CurrencyHistory200TextcsvResponse{Body: body, ContentLength: int64(body.Len())}, nil
+			}
+			return server.GetApiV1CurrencyHistory200ApplicationxmlResponse{Body: body, ContentLength: int64(body.Len())}, nil
+		default:
+			// Errors and empty responses are always sent as they are.
+			return response, nil
+		}
+	}
+}
+
+// negotiateMediaType picks the best supported media type for the Accept header.
+// An empty header accepts anything.
+func negotiateMediaType(accept string) (string, bool) {
+	if strings.TrimSpace(accept) == "" {
+		return supportedMediaTypes[0], true
+	}
+
+	type mediaRange struct {
+		mediaType string
+		quality   float64
+	}
+
+	var ranges []mediaRange
+	for part := range strings.SplitSeq(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			quality, err = strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+		}
+
+		if quality <= 0 {
+			continue
+		}
+
+		// text/xml is an older alias that some clients still send.
+		if mediaType == "text/xml" {
+			mediaType = mediaTypeXML
+		}
+
+		ranges = append(ranges, mediaRange{mediaType: mediaType, quality: quality})
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].quality > ranges[j].quality
+	})
+
+	for _, r := range ranges {
+		for _, supported := range supportedMediaTypes {
+			if mediaTypeMatches(r.mediaType, supported) {
+				return supported, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+func mediaTypeMatches(pattern, mediaType string) bool {
+	if pattern == "*/*" || pattern == mediaType {
+		return true
+	}
+
+	prefix, ok := strings.CutSuffix(pattern, "/*")
+	return ok && strings.HasPrefix(mediaType, prefix+"/")
+}
+
+type xmlRate struct {
+	XMLName     xml.Name  `xml:"rate"`
+	Code        string    `xml:"code"`
+	Value       string    `xml:"value"`
+	Date        string    `xml:"date"`
+	PublishedAt time.Time `xml:"published_at"`
+	Base        string    `xml:"base"`
+	Type        string    `xml:"type"`
+}
+
+type xmlRates struct {
+	XMLName xml.Name  `xml:"rates"`
+	Rates   []xmlRate `xml:"rate"`
+}
+
+func encodeRates(mediaType string, rates []server.Rate, list bool) (*bytes.Buffer, error) {
+	buf := &bytes.Buffer{}
+
+	switch mediaType {
+	case mediaTypeCSV:
+		w := csv.NewWriter(buf)
+		// base and type come last, so readers of the older columns keep working.
+		if err := w.Write([]string{"code", "value", "date", "published_at", "base", "type"}); err != nil {
+			return nil, err
+		}
+		for _, rate := range rates {
+			if err := w.Write([]string{rate.Code, rate.Value, rate.Date.String(), rate.PublishedAt.Format(time.RFC3339), rate.Base, string(rate.Type)}); err != nil {
+				return nil, err
+			}
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			return nil, err
+		}
+	case mediaTypeXML:
+		items := make([]xmlRate, len(rates))
+		for i, rate := range rates {
+			items[i] = xmlRate{Code: rate.Code, Value: rate.Value, Date: rate.Date.String(), PublishedAt: rate.PublishedAt, Base: rate.Base, Type: string(rate.Type)}
+		}
+
+		var doc any = xmlRates{Rates: items}
+		if !list && len(items) == 1 {
+			doc = items[0]
+		}
+
+		buf.WriteString(xml.Header)
+		if err := xml.NewEncoder(buf).Encode(doc); err != nil {
+			return nil, err
+		}
+	}
+
+	return buf, nil
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/zemzale/backscreen-home/pkg/server"
+)
+
+func TestNegotiateMediaType(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   string
+		ok     bool
+	}{
+		{accept: "", want: mediaTypeJSON, ok: true},
+		{accept: "*/*", want: mediaTypeJSON, ok: true},
+		{accept: "text/csv", want: mediaTypeCSV, ok: true},
+		{accept: "text/*", want: mediaTypeCSV, ok: true},
+		{accept: "text/xml", want: mediaTypeXML, ok: true},
+		{accept: "application/json;q=0.5, application/xml", want: mediaTypeXML, ok: true},
+		{accept: "text/csv;q=0.9, application/json;q=0.9", want: mediaTypeCSV, ok: true},
+		{accept: "application/json;q=0, text/csv", want: mediaTypeCSV, ok: true},
+		{accept: "image/png", ok: false},
+	}
+
+	for _, tt := range tests {
+		got, ok := negotiateMediaType(tt.accept)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("Expected %q to negotiate %q (%t), got %q (%t)", tt.accept, tt.want, tt.ok, got, ok)
+		}
+	}
+}
+
+func TestNegotiateMiddlewareRejectsUnknownFormat(t *testing.T) {
+	called := false
+	handler := negotiateMiddleware(func(ctx context.Context, w http.ResponseWriter, r *http.Request, request any) (any, error) {
+		called = true
+		return nil, nil
+	}, "GetApiV1CurrencyHistory")
+
+	format := server.GetApiV1CurrencyHistoryParamsFormat("yaml")
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/USD/history?format=yaml", nil)
+	r.Header.Set("Accept", "text/csv")
+
+	resp, err := handler(r.Context(), httptest.NewRecorder(), r, server.GetApiV1CurrencyHistoryRequestObject{
+		Currency: "USD",
+		Params:   server.GetApiV1CurrencyHistoryParams{Format: &format},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, ok := resp.(server.GetApiV1CurrencyHistory400JSONResponse); !ok {
+		t.Errorf("Expected a 400 response, got %T", resp)
+	}
+	if called {
+		t.Error("Expected the handler not to be called")
+	}
+}
+
+func TestEncodeRates(t *testing.T) {
+	rates := []server.Rate{{
+		Base:        "EUR",
+		Code:        "USD",
+		Value:       "1.1568",
+		Type:        server.RateType("reference"),
+		Date:        openapi_types.Date{Time: time.Date(2025, time.October, 10, 0, 0, 0, 0, time.UTC)},
+		PublishedAt: time.Date(2025, time.October, 10, 14, 0, 0, 0, time.UTC),
+	}}
+
+	tests := []struct {
+		name      string
+		mediaType string
+		list      bool
+		want      string
+	}{
+		{
+			name:      "csv",
+			mediaType: mediaTypeCSV,
+			want:      "code,value,date,published_at,base,type\nUSD,1.1568,2025-10-10,2025-10-10T14:00:00Z,EUR,reference\n",
+		},
+		{
+			name:      "xml single rate",
+			mediaType: mediaTypeXML,
+			want: xml.Header + "<rate><code>USD</code><value>1.1568</value><date>2025-10-10</date>" +
+				"<published_at>2025-10-10T14:00:00Z</published_at><base>EUR</base><type>reference</type></rate>",
+		},
+		{
+			name:      "xml list",
+			mediaType: mediaTypeXML,
+			list:      true,
+			want: xml.Header + "<rates><rate><code>USD</code><value>1.1568</value><date>2025-10-10</date>" +
+				"<published_at>2025-10-10T14:00:00Z</published_at><base>EUR</base><type>reference</type></rate></rates>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := encodeRates(tt.mediaType, rates, tt.list)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if body.String() != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, body.String())
+			}
+		})
+	}
+}
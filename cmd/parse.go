@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zemzale/backscreen-home/domain/entity"
+	"github.com/zemzale/backscreen-home/domain/mapper"
+	"github.com/zemzale/backscreen-home/slices"
+)
+
+var parseCmd = &cobra.Command{
+	Use:   "parse [file]",
+	Short: "Parse a saved feed file",
+	Long: `Parse a saved bank.lv RSS feed and print the rates, without touching the database.
+Reads stdin when no file or - is given. Exits with an error pointing at the item and token on malformed input.
+With --lenient the items that could be parsed are printed and every malformed item is reported.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+		currencies, _ := flags.GetStringSlice("currency")
+		format, _ := flags.GetString("format")
+		lenient, _ := flags.GetBool("lenient")
+
+		if format != "table" && format != "json" {
+			return fmt.Errorf("unknown format %q, expected table or json", format)
+		}
+
+		var r io.Reader = cmd.InOrStdin()
+		name := "stdin"
+		if len(args) == 1 && args[0] != "-" {
+			file, err := os.Open(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to open feed: %w", err)
+			}
+			defer file.Close()
+			r, name = file, args[0]
+		}
+
+		if !lenient {
+			rates, err := mapper.RatesFromXML(r)
+			if err != nil {
+				return describeParseError(name, err)
+			}
+			return writeParsedRates(cmd.OutOrStdout(), format, filterRates(rates, currencies))
+		}
+
+		rates, itemErrs, err := mapper.RatesFromXMLLenient(r)
+		if err != nil {
+			return describeParseError(name, err)
+		}
+
+		if err := writeParsedRates(cmd.OutOrStdout(), format, filterRates(rates, currencies)); err != nil {
+			return err
+		}
+
+		for _, itemErr := range itemErrs {
+			fmt.Fprintf(cmd.ErrOrStderr(), "%s: skipped %s\n", name, itemErr)
+		}
+		if len(itemErrs) > 0 {
+			return fmt.Errorf("%s: skipped %d malformed items", name, len(itemErrs))
+		}
+		return nil
+	},
+}
+
+// describeParseError prefixes the error with the input name, and the line for XML syntax errors.
+func describeParseError(name string, err error) error {
+	var syntaxErr *xml.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return fmt.Errorf("%s:%d: %w", name, syntaxErr.Line, err)
+	}
+	return fmt.Errorf("%s: %w", name, err)
+}
+
+func filterRates(rates []entity.Rate, currencies []string) []entity.Rate {
+	if len(currencies) == 0 {
+		return rates
+	}
+
+	wanted := make(map[string]bool, len(currencies))
+	for _, code := range currencies {
+		wanted[strings.ToUpper(code)] = true
+	}
+
+	return slices.FilterInPlace(rates, func(rate entity.Rate) bool {
+		return wanted[rate.Code]
+	})
+}
+
+type parsedRate struct {
+	Code        string    `json:"code"`
+	Value       string    `json:"value"`
+	Date        string    `json:"date"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+func writeParsedRates(w io.Writer, format string, rates []entity.Rate) error {
+	if format == "json" {
+		items := make([]parsedRate, len(rates))
+		for i, rate := range rates {
+			items[i] = parsedRate{Code: rate.Code, Value: rate.Value, Date: rate.Date.Format(time.DateOnly), PublishedAt: rate.PublishedAt}
+		}
+
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(items)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "DATE\tPUBLISHED_AT\tCODE\tVALUE")
+	for _, rate := range rates {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", rate.Date.Format(time.DateOnly), rate.PublishedAt.Format(time.RFC3339), rate.Code, rate.Value)
+	}
+	return tw.Flush()
+}
+
+func init() {
+	flags := parseCmd.Flags()
+	flags.StringSlice("currency", nil, "only print these currency codes")
+	flags.String("format", "table", "output format: table or json")
+	flags.Bool("lenient", false, "print the items that could be parsed and report the malformed ones")
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zemzale/backscreen-home/domain/entity"
+	"github.com/zemzale/backscreen-home/domain/usecase/quarantine"
+	"github.com/zemzale/backscreen-home/storage"
+)
+
+var quarantineCmd = &cobra.Command{
+	Use:   "quarantine",
+	Short: "Review quarantined rates",
+	Long: `List, approve and reject the rates the sync held back because they failed the sanity checks.
+Approved rates are stored with the other rates, rejected ones are kept out for good.`,
+}
+
+var quarantineListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List quarantined rates",
+	Args:  cobra.NoArgs,
+	RunE: withStore(func(cmd *cobra.Command, args []string, store *storage.Client) error {
+		ctx := cmd.Context()
+
+		statusStr, _ := cmd.Flags().GetString("status")
+		status, err := entity.ParseQuarantineStatus(statusStr)
+		if err != nil {
+			return err
+		}
+
+		rates, err := quarantine.New(store).List(ctx, status)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tCODE\tVALUE\tDATE\tSOURCE\tREASON\tREVIEWED BY\tREVIEW REASON")
+		for _, rate := range rates {
+			reviewedBy, reviewReason := "-", "-"
+			if rate.ReviewedAt != nil {
+				reviewedBy, reviewReason = rate.ReviewedBy, rate.ReviewReason
+			}
+
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				rate.ID, rate.Rate.Code, rate.Rate.Value, rate.Rate.Date.Format(time.DateOnly), rate.Source, rate.Reason, reviewedBy, reviewReason,
+			)
+		}
+
+		return w.Flush()
+	}),
+}
+
+var quarantineApproveCmd = &cobra.Command{
+	Use:   "approve <id>",
+	Short: "Store a quarantined rate with the other rates",
+	Args:  cobra.ExactArgs(1),
+	RunE: withStore(func(cmd *cobra.Command, args []string, store *storage.Client) error {
+		return reviewQuarantinedRate(cmd, args, store, entity.QuarantineApproved)
+	}),
+}
+
+var quarantineRejectCmd = &cobra.Command{
+	Use:   "reject <id>",
+	Short: "Keep a quarantined rate out of the stored rates",
+	Args:  cobra.ExactArgs(1),
+	RunE: withStore(func(cmd *cobra.Command, args []string, store *storage.Client) error {
+		return reviewQuarantinedRate(cmd, args, store, entity.QuarantineRejected)
+	}),
+}
+
+func reviewQuarantinedRate(cmd *cobra.Command, args []string, store *storage.Client, status entity.QuarantineStatus) error {
+	ctx := cmd.Context()
+
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid quarantined rate id %q: %w", args[0], err)
+	}
+
+	reviewer, _ := cmd.Flags().GetString("reviewer")
+	reason, _ := cmd.Flags().GetString("reason")
+
+	review := quarantine.New(store).Reject
+	if status == entity.QuarantineApproved {
+		review = quarantine.New(store).Approve
+	}
+
+	rate, err := review(ctx, id, reviewer, reason)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "Quarantined rate %d (%s %s on %s) %s by %s\n",
+		rate.ID, rate.Rate.Code, rate.Rate.Value, rate.Rate.Date.Format(time.DateOnly), rate.Status, rate.ReviewedBy,
+	)
+
+	return nil
+}
+
+func init() {
+	quarantineListCmd.Flags().String("status", string(entity.QuarantinePending), "list rates with this status: pending, approved or rejected")
+
+	for _, cmd := range []*cobra.Command{quarantineApproveCmd, quarantineRejectCmd} {
+		cmd.Flags().String("reason", "", "why the rate is approved or rejected")
+		cmd.Flags().String("reviewer", os.Getenv("USER"), "who reviewed the rate")
+		_ = cmd.MarkFlagRequired("reason")
+	}
+
+	quarantineCmd.AddCommand(quarantineListCmd)
+	quarantineCmd.AddCommand(quarantineApproveCmd)
+	quarantineCmd.AddCommand(quarantineRejectCmd)
+}
@@ -1,16 +1,39 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/zemzale/backscreen-home/adapter/database"
+	"github.com/zemzale/backscreen-home/pkg/logging"
+	"github.com/zemzale/backscreen-home/pkg/metrics"
+	"github.com/zemzale/backscreen-home/pkg/telemetry"
 	"github.com/zemzale/backscreen-home/storage"
 )
 
-var store *storage.Client
+// The storage client is only created through openStore.
+var (
+	storeOnce   sync.Once
+	sharedStore *storage.Client
+	storeErr    error
+)
+
+// logLevels can be changed at runtime through the admin API or SIGUSR1.
+var logLevels = logging.NewLevels(slog.LevelInfo, nil)
+
+// closeLog releases the log file when logs are written to one.
+var closeLog = func() error { return nil }
+
+// shutdownTracing flushes the spans that are still buffered, it is set up before any command runs.
+var shutdownTracing = func(context.Context) error { return nil }
 
 var rootCmd = &cobra.Command{
 	Use:   "backscreen-home",
@@ -19,40 +42,218 @@ var rootCmd = &cobra.Command{
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 		ctx := cmd.Context()
 
-		logger := slog.With("component", "root")
+		if err := loadConfig(cmd); err != nil {
+			return err
+		}
+
+		if err := setupLogging(ctx); err != nil {
+			return err
+		}
 
-		logger.DebugContext(ctx, "Connecting to database")
-		db, err := database.New(&database.Config{
-			Host:     viper.GetString("database.host"),
-			Port:     viper.GetInt("database.port"),
-			User:     viper.GetString("database.user"),
-			Password: viper.GetString("database.password"),
-			Database: viper.GetString("database.database"),
+		shutdown, err := telemetry.Setup(ctx, telemetry.Config{
+			Exporter:    viper.GetString("telemetry.exporter"),
+			Endpoint:    viper.GetString("telemetry.endpoint"),
+			Insecure:    viper.GetBool("telemetry.insecure"),
+			File:        viper.GetString("telemetry.file"),
+			SampleRatio: viper.GetFloat64("telemetry.sample_ratio"),
 		})
 		if err != nil {
-			return fmt.Errorf("failed to connect to database: %w", err)
+			return fmt.Errorf("failed to set up tracing: %w", err)
 		}
+		shutdownTracing = shutdown
 
-		logger.DebugContext(ctx, "Creating storage client")
-		store = storage.New(db)
+		return nil
+	},
+}
 
-		if err := store.Migrate(ctx); err != nil {
-			return fmt.Errorf("failed to migrate database: %w", err)
+// withStore declares that a command needs the storage client, it is passed to run once connected.
+func withStore(run func(cmd *cobra.Command, args []string, store *storage.Client) error) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		store, err := openStore(cmd.Context())
+		if err != nil {
+			return err
 		}
+		return run(cmd, args, store)
+	}
+}
 
-		return nil
-	},
+// openStore connects to the database and migrates it the first time a command needs it,
+// so commands that don't touch rates work without a database.
+func openStore(ctx context.Context) (*storage.Client, error) {
+	storeOnce.Do(func() {
+		sharedStore, storeErr = connectStore(ctx)
+	})
+	return sharedStore, storeErr
+}
+
+func connectStore(ctx context.Context) (*storage.Client, error) {
+	logger := slog.With("component", "root")
+
+	if err := validateDatabaseConfig(viper.GetViper()); err != nil {
+		return nil, err
+	}
+
+	dbConfig := &database.Config{
+		Host:     viper.GetString("database.host"),
+		Port:     viper.GetInt("database.port"),
+		User:     viper.GetString("database.user"),
+		Password: viper.GetString("database.password"),
+		Database: viper.GetString("database.database"),
+		TLS: database.TLSConfig{
+			Enabled:    viper.GetBool("database.tls.enabled"),
+			CA:         viper.GetString("database.tls.ca"),
+			Cert:       viper.GetString("database.tls.cert"),
+			Key:        viper.GetString("database.tls.key"),
+			ServerName: viper.GetString("database.tls.server_name"),
+			SkipVerify: viper.GetBool("database.tls.skip_verify"),
+		},
+		Pool: database.PoolConfig{
+			MaxOpenConns:    viper.GetInt("database.pool.max_open_conns"),
+			MaxIdleConns:    viper.GetInt("database.pool.max_idle_conns"),
+			ConnMaxLifetime: viper.GetDuration("database.pool.conn_max_lifetime"),
+			ConnMaxIdleTime: viper.GetDuration("database.pool.conn_max_idle_time"),
+		},
+		Retry: database.RetryConfig{
+			Timeout:        viper.GetDuration("database.connect.timeout"),
+			InitialBackoff: viper.GetDuration("database.connect.initial_backoff"),
+			MaxBackoff:     viper.GetDuration("database.connect.max_backoff"),
+		},
+	}
+
+	logger.DebugContext(ctx, "Connecting to database", slog.Any("database", dbConfig))
+	db, err := database.New(ctx, dbConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	metrics.RegisterDB(db.DB)
+
+	if interval := viper.GetDuration("database.pool.check_interval"); interval > 0 {
+		go database.Monitor(ctx, db.DB, interval)
+	}
+
+	logger.DebugContext(ctx, "Creating storage client")
+	client := storage.New(db)
+
+	if err := client.Migrate(ctx); err != nil {
+		return nil, fmt.Errorf("failed to migrate database: %w", err)
+	}
+
+	return client, nil
+}
+
+func setupLogging(ctx context.Context) error {
+	level, err := logging.ParseLevel(viper.GetString("log.level"))
+	if err != nil {
+		return err
+	}
+
+	components, err := logging.ParseComponentLevels(viper.GetString("log.components"))
+	if err != nil {
+		return err
+	}
+
+	levels, closer, err := logging.Setup(logging.Config{
+		Level:      level,
+		Format:     viper.GetString("log.format"),
+		Output:     viper.GetString("log.output"),
+		Components: components,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set up logging: %w", err)
+	}
+	logLevels = levels
+	closeLog = closer.Close
+
+	// SIGUSR1 toggles debug logging without a restart, for when the admin API is not reachable.
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGUSR1)
+	go func() {
+		for range sigChan {
+			next := slog.LevelDebug
+			if logLevels.Level() == slog.LevelDebug {
+				next = level
+				if next == slog.LevelDebug {
+					next = slog.LevelInfo
+				}
+			}
+			logLevels.SetLevel(next)
+			slog.With("component", "root").InfoContext(ctx, "Changed log level", slog.String("level", next.String()))
+		}
+	}()
+
+	return nil
 }
 
 func Execute() error {
-	return rootCmd.Execute()
+	err := rootCmd.Execute()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if shutdownErr := shutdownTracing(ctx); shutdownErr != nil {
+		slog.Error("Failed to flush traces", slog.Any("error", shutdownErr))
+	}
+
+	if closeErr := closeLog(); closeErr != nil {
+		fmt.Fprintf(os.Stderr, "failed to close log file: %v\n", closeErr)
+	}
+
+	return err
 }
 
 func init() {
-	// Setup Viper to work with environment variables
-	viper.SetEnvPrefix("BACKSCREEN")
-	viper.AutomaticEnv()
+	flags := rootCmd.PersistentFlags()
+	flags.String("config", "", "config file (YAML or TOML), defaults to backscreen.yaml in . or /etc/backscreen-home")
+	flags.String("log-level", "info", "log level: debug, info, warn or error")
+	flags.String("log-format", logging.FormatText, "log format: text or json")
+	flags.String("log-output", "stderr", "where logs are written: stderr, stdout or a file path")
+	flags.String("log-components", "", "per component log levels, e.g. sync=debug,api=warn")
+	flags.String("database-host", "127.0.0.1", "database host")
+	flags.Int("database-port", 3306, "database port")
+	flags.String("database-user", "", "database user")
+	flags.String("database-name", "backscreen_home", "database name")
+
+	_ = viper.BindPFlag("log.level", flags.Lookup("log-level"))
+	_ = viper.BindPFlag("log.format", flags.Lookup("log-format"))
+	_ = viper.BindPFlag("log.output", flags.Lookup("log-output"))
+	_ = viper.BindPFlag("log.components", flags.Lookup("log-components"))
+	_ = viper.BindPFlag("database.host", flags.Lookup("database-host"))
+	_ = viper.BindPFlag("database.port", flags.Lookup("database-port"))
+	_ = viper.BindPFlag("database.user", flags.Lookup("database-user"))
+	_ = viper.BindPFlag("database.database", flags.Lookup("database-name"))
+	// The password has no flag, so it doesn't end up in the shell history or the process list.
+	viper.SetDefault("database.password", "")
+	viper.SetDefault("database.user_file", "")
+	viper.SetDefault("database.password_file", "")
+	viper.SetDefault("database.tls.enabled", false)
+	viper.SetDefault("database.tls.ca", "")
+	viper.SetDefault("database.tls.cert", "")
+	viper.SetDefault("database.tls.key", "")
+	viper.SetDefault("database.tls.server_name", "")
+	viper.SetDefault("database.tls.skip_verify", false)
+	viper.SetDefault("database.pool.max_open_conns", 10)
+	viper.SetDefault("database.pool.max_idle_conns", 5)
+	viper.SetDefault("database.pool.conn_max_lifetime", 5*time.Minute)
+	viper.SetDefault("database.pool.conn_max_idle_time", time.Minute)
+	viper.SetDefault("database.pool.check_interval", 30*time.Second)
+	viper.SetDefault("database.connect.timeout", 30*time.Second)
+	viper.SetDefault("database.connect.initial_backoff", 500*time.Millisecond)
+	viper.SetDefault("database.connect.max_backoff", 5*time.Second)
+
+	viper.SetDefault("telemetry.endpoint", "")
+	viper.SetDefault("telemetry.insecure", false)
+	viper.SetDefault("telemetry.exporter", telemetry.ExporterNone)
+	viper.SetDefault("telemetry.file", "traces.jsonl")
+	viper.SetDefault("telemetry.sample_ratio", 1.0)
 
 	rootCmd.AddCommand(apiCmd)
 	rootCmd.AddCommand(syncCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(keysCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(parseCmd)
+	rootCmd.AddCommand(importCmd)
+	rootCmd.AddCommand(quarantineCmd)
 }
@@ -1,12 +1,26 @@
 package cmd
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"maps"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/zemzale/backscreen-home/adapter/eurofxref"
 	"github.com/zemzale/backscreen-home/adapter/lvbank"
+	"github.com/zemzale/backscreen-home/domain/anomaly"
 	"github.com/zemzale/backscreen-home/domain/usecase/syncer"
+	"github.com/zemzale/backscreen-home/pkg/metrics"
 	"github.com/zemzale/backscreen-home/primitives"
+	"github.com/zemzale/backscreen-home/storage"
 )
 
 var allowedCurrencies = []string{"AUD", "BGN", "BRL", "CAD", "CHF", "CNY", "CZK", "DKK", "GBP", "HKD"}
@@ -14,21 +28,247 @@ var allowedCurrencies = []string{"AUD", "BGN", "BRL", "CAD", "CHF", "CNY", "CZK"
 var syncCmd = &cobra.Command{
 	Use:   "sync",
 	Short: "Sync currency exchange rates",
-	Long:  `Sync currency exchange rates from the source to the database.`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		// TODO: Implement the sync
+	Long: `Sync currency exchange rates from the source to the database.
+
+With --interval the sync keeps running and syncs again after every interval,
+but never more often than the ttl of the feed allows.`,
+	RunE: withStore(func(cmd *cobra.Command, args []string, store *storage.Client) error {
 		ctx := cmd.Context()
 
 		logger := slog.With("component", "sync")
 
-		logger.InfoContext(ctx, "Starting syncing currencies")
+		usecase := syncer.New(store, fetchers(), syncerOptions()...)
+
+		interval := viper.GetDuration("sync.interval")
+		if interval <= 0 {
+			runSync(ctx, usecase)
+			return nil
+		}
+
+		ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+
+		for {
+			report := runSync(ctx, usecase)
+
+			wait := interval
+			if ttl := report.TTL(); ttl > wait {
+				logger.DebugContext(ctx, "Interval is shorter than the feed ttl, waiting for the ttl",
+					slog.Duration("interval", interval),
+					slog.Duration("ttl", ttl),
+				)
+				wait = ttl
+			}
+
+			logger.InfoContext(ctx, "Next sync scheduled", slog.Time("at", time.Now().Add(wait)))
+
+			select {
+			case <-ctx.Done():
+				logger.InfoContext(ctx, "Stopping scheduled sync")
+				return nil
+			case <-time.After(wait):
+			}
+		}
+	}),
+}
+
+// runSync syncs once and hands the metrics off.
+func runSync(ctx context.Context, usecase *syncer.Usecase) syncer.Report {
+	logger := slog.With("component", "sync")
+
+	logger.InfoContext(ctx, "Starting syncing currencies")
+
+	report := usecase.Sync(ctx, allowedCurrencies)
 
-		syncer.New(
-			store,
-			lvbank.New(primitives.NewHTTPClient()),
-		).Sync(ctx, allowedCurrencies)
+	logger.InfoContext(ctx, "Finished syncing currencies", slog.Any("report", report))
 
-		logger.InfoContext(ctx, "Finished syncing currencies")
-		return nil
+	// The sync is too short-lived to be scraped, so metrics are handed off before exiting.
+	if url := viper.GetString("sync.metrics.pushgateway"); url != "" {
+		logger.DebugContext(ctx, "Pushing metrics", slog.String("url", url))
+		if err := metrics.Push(ctx, url, "backscreen_sync"); err != nil {
+			logger.ErrorContext(ctx, "Failed to push metrics", slog.Any("error", err))
+		}
+	}
+
+	if path := viper.GetString("sync.metrics.textfile"); path != "" {
+		logger.DebugContext(ctx, "Writing metrics textfile", slog.String("path", path))
+		if err := metrics.WriteTextfile(path); err != nil {
+			logger.ErrorContext(ctx, "Failed to write metrics textfile", slog.Any("error", err))
+		}
+	}
+
+	return report
+}
+
+// sources are the fetchers the sync can use by name.
+var sources = map[string]func(lenient bool) syncer.RateFetcher{
+	"lvbank": func(lenient bool) syncer.RateFetcher {
+		var opts []lvbank.Option
+		if lenient {
+			opts = append(opts, lvbank.WithLenientParsing())
+		}
+		return lvbank.New(primitives.NewHTTPClient(), opts...)
+	},
+	"ecb": func(lenient bool) syncer.RateFetcher {
+		var opts []eurofxref.Option
+		if lenient {
+			opts = append(opts, eurofxref.WithLenientParsing())
+		}
+		return eurofxref.New(primitives.NewHTTPClient(), opts...)
 	},
 }
+
+// parseSources parses a comma separated list of source names, e.g. "lvbank,ecb".
+func parseSources(s string) ([]string, error) {
+	var names []string
+	for name := range strings.SplitSeq(s, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if _, ok := sources[name]; !ok {
+			return nil, fmt.Errorf("unknown source %q, expected one of %s", name, strings.Join(slices.Sorted(maps.Keys(sources)), ", "))
+		}
+		if slices.Contains(names, name) {
+			return nil, fmt.Errorf("source %q is listed twice", name)
+		}
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return nil, errors.New("at least one source must be set")
+	}
+	return names, nil
+}
+
+// parseFallbacks parses comma separated "<currency>=<source>|<source>" fallback chains,
+// e.g. "GBP=ecb,CNY=". An empty chain disables the fallback for the currency.
+func parseFallbacks(s string) (map[string][]string, error) {
+	chains := map[string][]string{}
+
+	for pair := range strings.SplitSeq(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		code, chainStr, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid fallback chain %q, expected <currency>=<source>|<source>", pair)
+		}
+
+		chain := []string{}
+		if strings.TrimSpace(chainStr) != "" {
+			var err error
+			if chain, err = parseSources(strings.ReplaceAll(chainStr, "|", ",")); err != nil {
+				return nil, fmt.Errorf("invalid fallback chain %q: %w", pair, err)
+			}
+		}
+
+		chains[strings.TrimSpace(code)] = chain
+	}
+
+	return chains, nil
+}
+
+// fetchers builds the configured sources in order of preference, they were already validated with the rest of the config.
+func fetchers() []syncer.RateFetcher {
+	names, _ := parseSources(viper.GetString("sync.sources"))
+	return buildFetchers(names, nil)
+}
+
+// fallbacks builds the default fallback chain and the per currency overrides. Primary sources
+// are left out of the chains, they were fetched already.
+func fallbacks() ([]syncer.RateFetcher, map[string][]syncer.RateFetcher) {
+	primary, _ := parseSources(viper.GetString("sync.sources"))
+
+	var defaults []syncer.RateFetcher
+	if s := viper.GetString("sync.fallback.default"); strings.TrimSpace(s) != "" {
+		names, _ := parseSources(s)
+		defaults = buildFetchers(names, primary)
+	}
+
+	names, _ := parseFallbacks(viper.GetString("sync.fallback.currencies"))
+	chains := make(map[string][]syncer.RateFetcher, len(names))
+	for code, chain := range names {
+		chains[code] = buildFetchers(chain, primary)
+	}
+
+	return defaults, chains
+}
+
+func buildFetchers(names, skip []string) []syncer.RateFetcher {
+	fetchers := make([]syncer.RateFetcher, 0, len(names))
+	for _, name := range names {
+		if !slices.Contains(skip, name) {
+			fetchers = append(fetchers, sources[name](viper.GetBool("sync.lenient")))
+		}
+	}
+	return fetchers
+}
+
+func syncerOptions() []syncer.Option {
+	var opts []syncer.Option
+	if viper.GetBool("sync.force") {
+		opts = append(opts, syncer.WithForce())
+	}
+	opts = append(opts, syncer.WithTolerance(viper.GetFloat64("sync.reconcile.tolerance")))
+	opts = append(opts, syncer.WithFallbacks(fallbacks()))
+	if viper.GetBool("sync.validation.enabled") {
+		opts = append(opts, syncer.WithChecker(anomaly.New(validationConfig())))
+	}
+	return opts
+}
+
+// validationConfig reads the sanity check settings, they were already validated with the rest of the config.
+func validationConfig() anomaly.Config {
+	maxChanges, _ := anomaly.ParseMaxChanges(viper.GetString("sync.validation.max_changes"))
+	pegs, _ := anomaly.ParsePegs(viper.GetString("sync.validation.pegs"))
+
+	var expected []string
+	for code := range strings.SplitSeq(viper.GetString("sync.validation.expected"), ",") {
+		if code = strings.TrimSpace(code); code != "" {
+			expected = append(expected, code)
+		}
+	}
+	if len(expected) == 0 {
+		expected = allowedCurrencies
+	}
+
+	return anomaly.Config{
+		MaxChange:  viper.GetFloat64("sync.validation.max_change"),
+		MaxChanges: maxChanges,
+		Pegs:       pegs,
+		Expected:   expected,
+	}
+}
+
+func init() {
+	flags := syncCmd.Flags()
+	flags.Bool("lenient", false, "skip malformed feed items instead of failing the sync")
+	flags.Bool("force", false, "sync even when the feed didn't change since the last sync")
+	flags.Duration("interval", 0, "keep running and sync every interval, at least the feed ttl apart")
+	flags.String("metrics-pushgateway", "", "Pushgateway URL to push sync metrics to")
+	flags.String("metrics-textfile", "", "file to write sync metrics to for the node exporter textfile collector")
+
+	_ = viper.BindPFlag("sync.lenient", flags.Lookup("lenient"))
+	_ = viper.BindPFlag("sync.force", flags.Lookup("force"))
+	_ = viper.BindPFlag("sync.interval", flags.Lookup("interval"))
+	_ = viper.BindPFlag("sync.metrics.pushgateway", flags.Lookup("metrics-pushgateway"))
+	_ = viper.BindPFlag("sync.metrics.textfile", flags.Lookup("metrics-textfile"))
+
+	// The first source wins when the sources disagree and there is no majority.
+	viper.SetDefault("sync.sources", "lvbank")
+	// Both banks publish the ECB reference rates, so they should match to the last digit.
+	viper.SetDefault("sync.reconcile.tolerance", 0.0001)
+	// Currencies the primary sources have no rates for, e.g. because bank.lv is down, are synced
+	// from the first fallback that has them.
+	viper.SetDefault("sync.fallback.default", "ecb")
+	viper.SetDefault("sync.fallback.currencies", "")
+	viper.SetDefault("sync.validation.enabled", true)
+	viper.SetDefault("sync.validation.max_change", 0.1)
+	viper.SetDefault("sync.validation.max_changes", "")
+	// BGN is pegged to the euro through the currency board, DKK is held in the ERM II band.
+	viper.SetDefault("sync.validation.pegs", "BGN=1.95583:0.001,DKK=7.46038:0.0225")
+	// Empty expects every synced currency.
+	viper.SetDefault("sync.validation.expected", "")
+}
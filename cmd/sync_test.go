@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseFallbacks(t *testing.T) {
+	chains, err := parseFallbacks("GBP=ecb|lvbank, CNY=")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !slices.Equal(chains["GBP"], []string{"ecb", "lvbank"}) {
+		t.Errorf("Expected GBP to fall back to ecb then lvbank, got %v", chains["GBP"])
+	}
+	if chain, ok := chains["CNY"]; !ok || len(chain) != 0 {
+		t.Errorf("Expected an empty chain for CNY, got %v", chain)
+	}
+
+	for _, s := range []string{"GBP", "GBP=fed", "GBP=ecb|ecb"} {
+		if _, err := parseFallbacks(s); err == nil {
+			t.Errorf("Expected %q to be invalid", s)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"github.com/spf13/cobra"
+)
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print the version",
+	Long:  `Print the version, commit and Go version the binary was built with.`,
+	Args:  cobra.NoArgs,
+	// The version has to be available even when the config is broken.
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		info, ok := debug.ReadBuildInfo()
+		if !ok {
+			return fmt.Errorf("build info is not available")
+		}
+
+		w := cmd.OutOrStdout()
+		fmt.Fprintf(w, "version:  %s\n", info.Main.Version)
+		fmt.Fprintf(w, "go:       %s\n", info.GoVersion)
+
+		for _, setting := range info.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				fmt.Fprintf(w, "commit:   %s\n", setting.Value)
+			case "vcs.time":
+				fmt.Fprintf(w, "built:    %s\n", setting.Value)
+			case "vcs.modified":
+				fmt.Fprintf(w, "modified: %s\n", setting.Value)
+			case "GOOS", "GOARCH":
+				fmt.Fprintf(w, "%-9s %s\n", setting.Key+":", setting.Value)
+			}
+		}
+
+		return nil
+	},
+}
@@ -0,0 +1,163 @@
+// Package anomaly holds the sanity checks fetched rates have to pass before they are stored.
+package anomaly
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zemzale/backscreen-home/domain/entity"
+)
+
+// Peg is a currency with a fixed rate to the euro. Pegs only apply to the ECB reference rates.
+type Peg struct {
+	Rate float64
+	// Tolerance is the largest allowed relative deviation from Rate, e.g. 0.001 for 0.1%.
+	Tolerance float64
+}
+
+type Config struct {
+	// MaxChange is the largest relative change from the previous rate, e.g. 0.1 for 10%.
+	// Zero disables the check.
+	MaxChange float64
+	// MaxChanges overrides MaxChange for single currencies.
+	MaxChanges map[string]float64
+	Pegs       map[string]Peg
+	// Expected are the currencies every feed item of ECB reference rates has to contain.
+	Expected []string
+}
+
+type Checker struct {
+	cfg Config
+}
+
+func New(cfg Config) *Checker {
+	return &Checker{cfg: cfg}
+}
+
+// Missing returns the expected currencies the item has no ECB reference rate for. Items of
+// other bases and rate types don't have to contain them.
+func (c *Checker) Missing(item entity.FeedItem) []string {
+	found := make(map[string]bool, len(item.Rates))
+	for _, rate := range item.Rates {
+		if isReference(rate) {
+			found[rate.Code] = true
+		}
+	}
+	if len(found) == 0 {
+		return nil
+	}
+
+	var missing []string
+	for _, code := range c.cfg.Expected {
+		if !found[code] {
+			missing = append(missing, code)
+		}
+	}
+	return missing
+}
+
+// Check returns why the rate looks wrong, or an empty string when it passes. previous is the
+// newest accepted rate of the currency before it, the zero Rate when there is none.
+func (c *Checker) Check(rate, previous entity.Rate) string {
+	value, err := strconv.ParseFloat(rate.Value, 64)
+	if err != nil || value <= 0 {
+		return fmt.Sprintf("%s is not a positive rate", rate.Value)
+	}
+
+	var reasons []string
+
+	if peg, ok := c.cfg.Pegs[rate.Code]; ok && isReference(rate) {
+		if deviation := math.Abs(value/peg.Rate - 1); deviation > peg.Tolerance {
+			reasons = append(reasons, fmt.Sprintf("pegged at %s but off by %s (max %s)",
+				formatFloat(peg.Rate), formatPercent(deviation), formatPercent(peg.Tolerance)))
+		}
+	}
+
+	maxChange := c.cfg.MaxChange
+	if override, ok := c.cfg.MaxChanges[rate.Code]; ok {
+		maxChange = override
+	}
+
+	if previousValue, err := strconv.ParseFloat(previous.Value, 64); maxChange > 0 && err == nil && previousValue > 0 {
+		if change := math.Abs(value/previousValue - 1); change > maxChange {
+			reasons = append(reasons, fmt.Sprintf("changed by %s since %s, %s -> %s (max %s)",
+				formatPercent(change), previous.Date.Format(time.DateOnly), previous.Value, rate.Value, formatPercent(maxChange)))
+		}
+	}
+
+	return strings.Join(reasons, "; ")
+}
+
+// isReference reports whether the rate is an ECB reference rate against the euro.
+func isReference(rate entity.Rate) bool {
+	return rate.Series() == entity.ReferenceSeries(rate.Code)
+}
+
+func formatPercent(f float64) string {
+	return formatFloat(math.Round(f*10000)/100) + "%"
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// ParseMaxChanges parses comma separated "<currency>=<fraction>" pairs, e.g. "TRY=0.2,JPY=0.15".
+func ParseMaxChanges(s string) (map[string]float64, error) {
+	changes := map[string]float64{}
+
+	for pair := range strings.SplitSeq(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		code, changeStr, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid max change %q, expected <currency>=<fraction>", pair)
+		}
+
+		change, err := strconv.ParseFloat(strings.TrimSpace(changeStr), 64)
+		if err != nil || change < 0 {
+			return nil, fmt.Errorf("invalid max change %q, the fraction must be zero or positive", pair)
+		}
+
+		changes[strings.TrimSpace(code)] = change
+	}
+
+	return changes, nil
+}
+
+// ParsePegs parses comma separated "<currency>=<rate>:<tolerance>" pairs, e.g. "BGN=1.95583:0.001".
+func ParsePegs(s string) (map[string]Peg, error) {
+	pegs := map[string]Peg{}
+
+	for pair := range strings.SplitSeq(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		code, pegStr, ok := strings.Cut(pair, "=")
+		rateStr, toleranceStr, hasTolerance := strings.Cut(pegStr, ":")
+		if !ok || !hasTolerance {
+			return nil, fmt.Errorf("invalid peg %q, expected <currency>=<rate>:<tolerance>", pair)
+		}
+
+		rate, err := strconv.ParseFloat(strings.TrimSpace(rateStr), 64)
+		if err != nil || rate <= 0 {
+			return nil, fmt.Errorf("invalid peg %q, the rate must be positive", pair)
+		}
+
+		tolerance, err := strconv.ParseFloat(strings.TrimSpace(toleranceStr), 64)
+		if err != nil || tolerance < 0 {
+			return nil, fmt.Errorf("invalid peg %q, the tolerance must be zero or positive", pair)
+		}
+
+		pegs[strings.TrimSpace(code)] = Peg{Rate: rate, Tolerance: tolerance}
+	}
+
+	return pegs, nil
+}
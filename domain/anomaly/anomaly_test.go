@@ -0,0 +1,103 @@
+package anomaly
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zemzale/backscreen-home/domain/entity"
+)
+
+func rate(code, value string, day int) entity.Rate {
+	return entity.Rate{
+		Base:  entity.DefaultBase,
+		Code:  code,
+		Value: value,
+		Type:  entity.RateTypeReference,
+		Date:  time.Date(2025, time.October, day, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func series(r entity.Rate, base string, rateType entity.RateType) entity.Rate {
+	r.Base = base
+	r.Type = rateType
+	return r
+}
+
+func TestCheck(t *testing.T) {
+	checker := New(Config{
+		MaxChange:  0.1,
+		MaxChanges: map[string]float64{"TRY": 0.5},
+		Pegs:       map[string]Peg{"BGN": {Rate: 1.95583, Tolerance: 0.001}},
+	})
+
+	tests := []struct {
+		name     string
+		rate     entity.Rate
+		previous entity.Rate
+		reason   string
+	}{
+		{name: "no previous rate", rate: rate("USD", "1.1568", 10)},
+		{name: "small change", rate: rate("USD", "1.1568", 10), previous: rate("USD", "1.1600", 9)},
+		{name: "off by 1000x", rate: rate("USD", "1156.8", 10), previous: rate("USD", "1.1600", 9), reason: "changed by 99624.14% since 2025-10-09, 1.1600 -> 1156.8 (max 10%)"},
+		{name: "currency override", rate: rate("TRY", "60", 10), previous: rate("TRY", "48", 9)},
+		{name: "peg holds", rate: rate("BGN", "1.95580000", 10)},
+		{name: "peg broken", rate: rate("BGN", "1.9", 10), reason: "pegged at 1.95583 but off by 2.85% (max 0.1%)"},
+		{name: "peg of another base", rate: series(rate("BGN", "1.69", 10), "USD", entity.RateTypeReference)},
+		{name: "peg of another type", rate: series(rate("BGN", "1.9", 10), entity.DefaultBase, entity.RateTypeBuy)},
+		{name: "not a rate", rate: rate("USD", "0", 10), reason: "0 is not a positive rate"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := checker.Check(tt.rate, tt.previous); got != tt.reason {
+				t.Errorf("Expected reason %q, got %q", tt.reason, got)
+			}
+		})
+	}
+}
+
+func TestMissing(t *testing.T) {
+	checker := New(Config{Expected: []string{"USD", "GBP", "BGN"}})
+
+	item := entity.FeedItem{Rates: []entity.Rate{rate("USD", "1.1568", 10), rate("BGN", "1.9558", 10)}}
+	if got := checker.Missing(item); !slices.Equal(got, []string{"GBP"}) {
+		t.Errorf("Expected GBP to be missing, got %v", got)
+	}
+
+	item = entity.FeedItem{Rates: []entity.Rate{series(rate("USD", "1.1568", 10), entity.DefaultBase, entity.RateTypeBuy)}}
+	if got := checker.Missing(item); len(got) != 0 {
+		t.Errorf("Expected nothing to be missing from buy rates, got %v", got)
+	}
+}
+
+func TestParsePegs(t *testing.T) {
+	pegs, err := ParsePegs("BGN=1.95583:0.001, DKK=7.46038:0.0225")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if pegs["BGN"] != (Peg{Rate: 1.95583, Tolerance: 0.001}) || pegs["DKK"] != (Peg{Rate: 7.46038, Tolerance: 0.0225}) {
+		t.Errorf("Expected BGN and DKK pegs, got %v", pegs)
+	}
+
+	for _, s := range []string{"BGN", "BGN=1.95583", "BGN=x:0.1", "BGN=1.9:-1"} {
+		if _, err := ParsePegs(s); err == nil || !strings.Contains(err.Error(), "invalid peg") {
+			t.Errorf("Expected %q to be invalid, got %v", s, err)
+		}
+	}
+}
+
+func TestParseMaxChanges(t *testing.T) {
+	changes, err := ParseMaxChanges("TRY=0.2,JPY=0.15")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if changes["TRY"] != 0.2 || changes["JPY"] != 0.15 {
+		t.Errorf("Expected TRY and JPY overrides, got %v", changes)
+	}
+
+	if _, err := ParseMaxChanges("TRY"); err == nil {
+		t.Error("Expected a pair without fraction to be invalid")
+	}
+}
@@ -0,0 +1,58 @@
+package anomaly
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/zemzale/backscreen-home/domain/entity"
+)
+
+// Quote is the rate a single source published for a currency and reference date.
+type Quote struct {
+	Source string
+	Rate   entity.Rate
+}
+
+// Reconcile picks the quote that most quotes agree with, i.e. differ from by at most the
+// relative tolerance. Ties go to the earlier quote, so sources should be listed by preference.
+// ok is false when no value is backed by a strict majority, like two sources that disagree.
+// agrees reports for every quote whether it matches the agreed one.
+func Reconcile(quotes []Quote, tolerance float64) (agreed Quote, agrees []bool, ok bool) {
+	if len(quotes) == 0 {
+		return Quote{}, nil, false
+	}
+
+	values := make([]float64, len(quotes))
+	for i, quote := range quotes {
+		value, err := strconv.ParseFloat(quote.Rate.Value, 64)
+		if err != nil || value <= 0 {
+			// An unreadable value never agrees with anything, not even itself.
+			value = math.NaN()
+		}
+		values[i] = value
+	}
+
+	matches := func(a, b float64) bool {
+		return math.Abs(a/b-1) <= tolerance
+	}
+
+	best, bestCount := 0, -1
+	for i := range values {
+		count := 0
+		for j := range values {
+			if matches(values[i], values[j]) {
+				count++
+			}
+		}
+		if count > bestCount {
+			best, bestCount = i, count
+		}
+	}
+
+	agrees = make([]bool, len(quotes))
+	for j := range values {
+		agrees[j] = matches(values[best], values[j])
+	}
+
+	return quotes[best], agrees, bestCount*2 > len(quotes)
+}
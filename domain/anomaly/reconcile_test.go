@@ -0,0 +1,41 @@
+package anomaly
+
+import (
+	"slices"
+	"testing"
+)
+
+func quotes(values ...string) []Quote {
+	sources := []string{"lvbank", "ecb", "other"}
+	quotes := make([]Quote, len(values))
+	for i, value := range values {
+		quotes[i] = Quote{Source: sources[i], Rate: rate("USD", value, 10)}
+	}
+	return quotes
+}
+
+func TestReconcile(t *testing.T) {
+	tests := []struct {
+		name   string
+		quotes []Quote
+		agreed string
+		agrees []bool
+		ok     bool
+	}{
+		{name: "single source", quotes: quotes("1.15680000"), agreed: "lvbank", agrees: []bool{true}, ok: true},
+		{name: "same value in other notation", quotes: quotes("1.15680000", "1.1568"), agreed: "lvbank", agrees: []bool{true, true}, ok: true},
+		{name: "within tolerance", quotes: quotes("1.1568", "1.15681"), agreed: "lvbank", agrees: []bool{true, true}, ok: true},
+		{name: "two disagree", quotes: quotes("1.1568", "1.1600"), agreed: "lvbank", agrees: []bool{true, false}, ok: false},
+		{name: "majority wins", quotes: quotes("1156.8", "1.1568", "1.1568"), agreed: "ecb", agrees: []bool{false, true, true}, ok: true},
+		{name: "unreadable value", quotes: quotes("x", "1.1568", "1.1568"), agreed: "ecb", agrees: []bool{false, true, true}, ok: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agreed, agrees, ok := Reconcile(tt.quotes, 0.0001)
+			if agreed.Source != tt.agreed || !slices.Equal(agrees, tt.agrees) || ok != tt.ok {
+				t.Errorf("Expected %s %v %t, got %s %v %t", tt.agreed, tt.agrees, tt.ok, agreed.Source, agrees, ok)
+			}
+		})
+	}
+}
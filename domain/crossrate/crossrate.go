@@ -0,0 +1,133 @@
+// Package crossrate computes rates against another base from rates that share a pivot
+// currency, e.g. USD/GBP from EUR/USD and EUR/GBP.
+package crossrate
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/zemzale/backscreen-home/domain/entity"
+)
+
+// SignificantDigits is how many significant digits a computed rate keeps. The ECB publishes
+// five or six, so more would only be noise.
+const SignificantDigits = 6
+
+// Rebase turns rate, quoted against the pivot currency, into a rate against the base currency.
+// baseRate is the rate of the base currency against the same pivot, of the same type and
+// reference date. The value is computed exactly and rounded once, see Round.
+func Rebase(rate, baseRate entity.Rate) (entity.Rate, error) {
+	if rate.Base != baseRate.Base || rate.Type != baseRate.Type || !rate.Date.Equal(baseRate.Date) {
+		return entity.Rate{}, fmt.Errorf("can't cross %s on %s with %s on %s",
+			rate.Series(), rate.Date.Format("2006-01-02"), baseRate.Series(), baseRate.Date.Format("2006-01-02"))
+	}
+
+	value, err := parse(rate.Value)
+	if err != nil {
+		return entity.Rate{}, err
+	}
+	baseValue, err := parse(baseRate.Value)
+	if err != nil {
+		return entity.Rate{}, err
+	}
+
+	publishedAt := rate.PublishedAt
+	if baseRate.PublishedAt.After(publishedAt) {
+		publishedAt = baseRate.PublishedAt
+	}
+
+	return entity.Rate{
+		PublishedAt: publishedAt,
+		Date:        rate.Date,
+		Base:        baseRate.Code,
+		Code:        rate.Code,
+		Value:       Round(new(big.Rat).Quo(value, baseValue), SignificantDigits),
+		Type:        rate.Type,
+	}, nil
+}
+
+// Pivot returns the pivot currency itself against the base currency of baseRate, i.e. the
+// inverse of baseRate, e.g. EUR against USD from EUR/USD.
+func Pivot(baseRate entity.Rate) (entity.Rate, error) {
+	unit := baseRate
+	unit.Code = baseRate.Base
+	unit.Value = "1"
+	return Rebase(unit, baseRate)
+}
+
+// parse reads a decimal rate exactly, floats would already round the input.
+func parse(s string) (*big.Rat, error) {
+	value, ok := new(big.Rat).SetString(strings.TrimSpace(s))
+	if !ok || value.Sign() <= 0 {
+		return nil, fmt.Errorf("%q is not a positive decimal rate", s)
+	}
+	return value, nil
+}
+
+// Round formats a positive value as a decimal with the given number of significant digits,
+// rounding half to even. Trailing zeros after the decimal point are dropped, e.g. 0.8654321
+// becomes "0.865432" and 1 becomes "1".
+func Round(value *big.Rat, digits int) string {
+	ten := big.NewInt(10)
+	lower := new(big.Int).Exp(ten, big.NewInt(int64(digits-1)), nil)
+	upper := new(big.Int).Mul(lower, ten)
+
+	// Scale by 10^scale until the integer part has exactly digits digits, scale is negative
+	// for values of more than digits digits.
+	scale := 0
+	scaled := new(big.Rat).Set(value)
+	tenRat := new(big.Rat).SetInt(ten)
+	for scaled.Cmp(new(big.Rat).SetInt(lower)) < 0 {
+		scaled.Mul(scaled, tenRat)
+		scale++
+	}
+	for scaled.Cmp(new(big.Rat).SetInt(upper)) >= 0 {
+		scaled.Quo(scaled, tenRat)
+		scale--
+	}
+
+	n := roundHalfEven(scaled)
+	if n.Cmp(upper) == 0 {
+		// 999999.5 rounds up to a digit more, which is exact after dropping a zero.
+		n.Quo(n, ten)
+		scale--
+	}
+
+	return format(n, scale)
+}
+
+func roundHalfEven(r *big.Rat) *big.Int {
+	q, m := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+
+	switch new(big.Int).Mul(m, big.NewInt(2)).Cmp(r.Denom()) {
+	case 1:
+		q.Add(q, big.NewInt(1))
+	case 0:
+		if q.Bit(0) == 1 {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+
+	return q
+}
+
+// format writes n / 10^scale as a decimal without trailing zeros.
+func format(n *big.Int, scale int) string {
+	digits := n.String()
+
+	if scale <= 0 {
+		return digits + strings.Repeat("0", -scale)
+	}
+
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+
+	point := len(digits) - scale
+	fraction := strings.TrimRight(digits[point:], "0")
+	if fraction == "" {
+		return digits[:point]
+	}
+	return digits[:point] + "." + fraction
+}
@@ -0,0 +1,77 @@
+package crossrate
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/zemzale/backscreen-home/domain/entity"
+)
+
+func eurRate(code, value string) entity.Rate {
+	date := time.Date(2025, time.October, 10, 0, 0, 0, 0, time.UTC)
+	return entity.Rate{Base: "EUR", Code: code, Value: value, Type: entity.RateTypeReference, Date: date, PublishedAt: date.Add(14 * time.Hour)}
+}
+
+func TestRound(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "0.8654321", want: "0.865432"},
+		{value: "1", want: "1"},
+		{value: "152.5432109", want: "152.543"},
+		{value: "1234567.8", want: "1234570"},
+		{value: "0.0000604123456", want: "0.0000604123"},
+		// Ties go to the even digit.
+		{value: "1.000005", want: "1"},
+		{value: "1.000015", want: "1.00002"},
+		{value: "9.9999995", want: "10"},
+	}
+
+	for _, tt := range tests {
+		value, _ := new(big.Rat).SetString(tt.value)
+		if got := Round(value, SignificantDigits); got != tt.want {
+			t.Errorf("Expected %s to round to %s, got %s", tt.value, tt.want, got)
+		}
+	}
+}
+
+func TestRebase(t *testing.T) {
+	usd := eurRate("USD", "1.1568")
+
+	gbp, err := Rebase(eurRate("GBP", "0.87090"), usd)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	// 0.87090 / 1.1568 = 0.752852697...
+	if gbp.Base != "USD" || gbp.Code != "GBP" || gbp.Value != "0.752853" {
+		t.Errorf("Expected USD/GBP 0.752853, got %+v", gbp)
+	}
+	if !gbp.Date.Equal(usd.Date) || gbp.Type != entity.RateTypeReference {
+		t.Errorf("Expected the date and type of the inputs, got %+v", gbp)
+	}
+
+	eur, err := Pivot(usd)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	// 1 / 1.1568 = 0.864453665...
+	if eur.Base != "USD" || eur.Code != "EUR" || eur.Value != "0.864454" {
+		t.Errorf("Expected USD/EUR 0.864454, got %+v", eur)
+	}
+
+	self, _ := Rebase(usd, usd)
+	if self.Value != "1" {
+		t.Errorf("Expected USD/USD 1, got %s", self.Value)
+	}
+
+	other := eurRate("GBP", "0.87090")
+	other.Date = other.Date.AddDate(0, 0, -1)
+	if _, err := Rebase(other, usd); err == nil {
+		t.Error("Expected rates of different dates to be rejected")
+	}
+	if _, err := Rebase(eurRate("GBP", "x"), usd); err == nil {
+		t.Error("Expected an unreadable value to be rejected")
+	}
+}
@@ -0,0 +1,102 @@
+// Package ecb describes when the European Central Bank publishes its euro foreign exchange reference rates.
+package ecb
+
+import (
+	"time"
+	// The ECB publishes on Frankfurt time, embed the zone database so containers without tzdata work.
+	_ "time/tzdata"
+)
+
+// PublicationHour is the hour in Frankfurt local time at which the reference rates are usually published.
+const PublicationHour = 16
+
+var Location = mustLoadLocation("Europe/Berlin")
+
+func mustLoadLocation(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return loc
+}
+
+// IsBusinessDay reports whether the ECB publishes reference rates on the date.
+// Rates are not published on weekends and TARGET closing days.
+func IsBusinessDay(date time.Time) bool {
+	year, month, day := date.Date()
+
+	switch date.Weekday() {
+	case time.Saturday, time.Sunday:
+		return false
+	}
+
+	switch {
+	case month == time.January && day == 1,
+		month == time.May && day == 1,
+		month == time.December && (day == 25 || day == 26):
+		return false
+	}
+
+	easter := easterSunday(year)
+	goodFriday := easter.AddDate(0, 0, -2)
+	easterMonday := easter.AddDate(0, 0, 1)
+
+	d := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return !d.Equal(goodFriday) && !d.Equal(easterMonday)
+}
+
+// ReferenceDate returns the ECB reference date rates published at t belong to, as midnight UTC.
+// Rates are published during their reference day in Frankfurt, whatever zone t is in.
+func ReferenceDate(t time.Time) time.Time {
+	year, month, day := t.In(Location).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// NextPublication returns the first expected publication strictly after t.
+func NextPublication(t time.Time) time.Time {
+	local := t.In(Location)
+	candidate := publicationOn(local)
+
+	for !candidate.After(local) || !IsBusinessDay(candidate) {
+		candidate = publicationOn(candidate.AddDate(0, 0, 1))
+	}
+
+	return candidate
+}
+
+// LatestPublication returns the last expected publication at or before t.
+func LatestPublication(t time.Time) time.Time {
+	local := t.In(Location)
+	candidate := publicationOn(local)
+
+	for candidate.After(local) || !IsBusinessDay(candidate) {
+		candidate = publicationOn(candidate.AddDate(0, 0, -1))
+	}
+
+	return candidate
+}
+
+func publicationOn(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, PublicationHour, 0, 0, 0, Location)
+}
+
+// easterSunday computes the date of Easter Sunday in the Gregorian calendar (anonymous Gregorian algorithm).
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
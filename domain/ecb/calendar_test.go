@@ -0,0 +1,78 @@
+package ecb
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsBusinessDay(t *testing.T) {
+	tests := []struct {
+		date time.Time
+		want bool
+	}{
+		{date: time.Date(2025, time.October, 10, 0, 0, 0, 0, time.UTC), want: true},
+		{date: time.Date(2025, time.October, 11, 0, 0, 0, 0, time.UTC), want: false},
+		{date: time.Date(2025, time.April, 18, 0, 0, 0, 0, time.UTC), want: false},
+		{date: time.Date(2025, time.April, 21, 0, 0, 0, 0, time.UTC), want: false},
+		{date: time.Date(2025, time.April, 22, 0, 0, 0, 0, time.UTC), want: true},
+		{date: time.Date(2024, time.March, 29, 0, 0, 0, 0, time.UTC), want: false},
+		{date: time.Date(2025, time.December, 26, 0, 0, 0, 0, time.UTC), want: false},
+		{date: time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC), want: false},
+	}
+
+	for _, tt := range tests {
+		if got := IsBusinessDay(tt.date); got != tt.want {
+			t.Errorf("Expected IsBusinessDay(%s) to be %t, got %t", tt.date.Format(time.DateOnly), tt.want, got)
+		}
+	}
+}
+
+func TestNextPublication(t *testing.T) {
+	tests := []struct {
+		at   time.Time
+		want time.Time
+	}{
+		// Before the publication on a business day.
+		{at: time.Date(2025, time.October, 10, 9, 0, 0, 0, Location), want: time.Date(2025, time.October, 10, 16, 0, 0, 0, Location)},
+		// After the Friday publication the next one is on Monday.
+		{at: time.Date(2025, time.October, 10, 17, 0, 0, 0, Location), want: time.Date(2025, time.October, 13, 16, 0, 0, 0, Location)},
+		// Easter Friday and Monday are skipped.
+		{at: time.Date(2025, time.April, 17, 16, 0, 0, 0, Location), want: time.Date(2025, time.April, 22, 16, 0, 0, 0, Location)},
+	}
+
+	for _, tt := range tests {
+		if got := NextPublication(tt.at); !got.Equal(tt.want) {
+			t.Errorf("Expected next publication after %s to be %s, got %s", tt.at, tt.want, got)
+		}
+	}
+}
+
+func TestLatestPublication(t *testing.T) {
+	at := time.Date(2025, time.October, 13, 10, 0, 0, 0, Location)
+	want := time.Date(2025, time.October, 10, 16, 0, 0, 0, Location)
+
+	if got := LatestPublication(at); !got.Equal(want) {
+		t.Errorf("Expected latest publication before %s to be %s, got %s", at, want, got)
+	}
+}
+
+func TestReferenceDate(t *testing.T) {
+	riga := time.FixedZone("EEST", 3*60*60)
+
+	tests := []struct {
+		publishedAt time.Time
+		want        time.Time
+	}{
+		// bank.lv publishes at 03:00 Riga time, which is still the same day in Frankfurt.
+		{publishedAt: time.Date(2025, time.October, 10, 3, 0, 0, 0, riga), want: time.Date(2025, time.October, 10, 0, 0, 0, 0, time.UTC)},
+		{publishedAt: time.Date(2025, time.October, 10, 0, 0, 0, 0, time.UTC), want: time.Date(2025, time.October, 10, 0, 0, 0, 0, time.UTC)},
+		{publishedAt: time.Date(2025, time.October, 10, 16, 0, 0, 0, Location), want: time.Date(2025, time.October, 10, 0, 0, 0, 0, time.UTC)},
+		{publishedAt: time.Date(2025, time.October, 9, 22, 30, 0, 0, time.UTC), want: time.Date(2025, time.October, 10, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		if got := ReferenceDate(tt.publishedAt); !got.Equal(tt.want) || got.Location() != time.UTC {
+			t.Errorf("Expected ReferenceDate(%s) to be %s, got %s", tt.publishedAt, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+type Scope string
+
+const (
+	ScopeRead   Scope = "read"
+	ScopeExport Scope = "export"
+	ScopeAdmin  Scope = "admin"
+)
+
+var Scopes = []Scope{ScopeRead, ScopeExport, ScopeAdmin}
+
+func ParseScope(s string) (Scope, error) {
+	scope := Scope(s)
+	if !slices.Contains(Scopes, scope) {
+		return "", fmt.Errorf("unknown scope %q", s)
+	}
+
+	return scope, nil
+}
+
+type APIKey struct {
+	ID        int
+	Name      string
+	Prefix    string
+	Scopes    []Scope
+	CreatedAt time.Time
+	RevokedAt *time.Time
+}
+
+// HasScope reports whether the key was granted the scope. Admin keys are granted every scope.
+func (k APIKey) HasScope(scope Scope) bool {
+	return slices.Contains(k.Scopes, ScopeAdmin) || slices.Contains(k.Scopes, scope)
+}
+
+func (k APIKey) Revoked() bool {
+	return k.RevokedAt != nil
+}
@@ -0,0 +1,36 @@
+package entity
+
+import "time"
+
+// Feed is everything a source published in a single download.
+type Feed struct {
+	Source string
+	// BuildDate is when the source last changed the feed, zero when it doesn't say.
+	BuildDate time.Time
+	// TTL is how long the source asks clients to wait between fetches, zero when it doesn't say.
+	TTL   time.Duration
+	Items []FeedItem
+}
+
+// FeedItem is a single publication in a feed.
+type FeedItem struct {
+	// GUID identifies the item in the feed, e.g. https://www.bank.lv/#10.10.
+	GUID        string
+	PublishedAt time.Time
+	// Raw is the item content as published, before it was parsed into rates.
+	Raw   string
+	Rates []Rate
+}
+
+// RatesOf returns the rates of all items for the currency.
+func (f Feed) RatesOf(currency string) []Rate {
+	var rates []Rate
+	for _, item := range f.Items {
+		for _, rate := range item.Rates {
+			if rate.Code == currency {
+				rates = append(rates, rate)
+			}
+		}
+	}
+	return rates
+}
@@ -0,0 +1,10 @@
+package entity
+
+// SourceRate is the value a single source published for a rate, kept to show where stored
+// rates came from.
+type SourceRate struct {
+	Source string
+	Rate   Rate
+	// Agrees is whether the value matches the agreed one within the reconciliation tolerance.
+	Agrees bool
+}
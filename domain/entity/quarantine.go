@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+type QuarantineStatus string
+
+const (
+	QuarantinePending  QuarantineStatus = "pending"
+	QuarantineApproved QuarantineStatus = "approved"
+	QuarantineRejected QuarantineStatus = "rejected"
+)
+
+var QuarantineStatuses = []QuarantineStatus{QuarantinePending, QuarantineApproved, QuarantineRejected}
+
+func ParseQuarantineStatus(s string) (QuarantineStatus, error) {
+	status := QuarantineStatus(s)
+	if !slices.Contains(QuarantineStatuses, status) {
+		return "", fmt.Errorf("unknown quarantine status %q", s)
+	}
+
+	return status, nil
+}
+
+// QuarantinedRate is a fetched rate that failed the sanity checks and waits for review
+// instead of being stored with the other rates.
+type QuarantinedRate struct {
+	ID     int
+	Rate   Rate
+	Source string
+	// Reason describes every check the rate failed.
+	Reason    string
+	Status    QuarantineStatus
+	CreatedAt time.Time
+	// ReviewedBy, ReviewReason and ReviewedAt are set once the rate was approved or rejected.
+	ReviewedBy   string
+	ReviewReason string
+	ReviewedAt   *time.Time
+}
@@ -1,9 +1,65 @@
 package entity
 
-import "time"
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// DefaultBase is the currency the ECB reference rates are quoted against.
+const DefaultBase = "EUR"
+
+// RateType tells which kind of rate a source publishes, central banks that publish exchange
+// tables often have separate buy and sell rates.
+type RateType string
+
+const (
+	RateTypeReference RateType = "reference"
+	RateTypeBuy       RateType = "buy"
+	RateTypeSell      RateType = "sell"
+)
+
+var RateTypes = []RateType{RateTypeReference, RateTypeBuy, RateTypeSell}
+
+func ParseRateType(s string) (RateType, error) {
+	rateType := RateType(s)
+	if !slices.Contains(RateTypes, rateType) {
+		return "", fmt.Errorf("unknown rate type %q", s)
+	}
+
+	return rateType, nil
+}
 
 type Rate struct {
+	// PublishedAt is when the source published the rate, in UTC.
 	PublishedAt time.Time
-	Code        string
-	Value       string
+	// Date is the ECB reference date of the rate, as midnight UTC.
+	Date time.Time
+	// Base is the currency the rate is quoted against, one Base buys Value of Code.
+	Base  string
+	Code  string
+	Value string
+	Type  RateType
+	// Source names where a stored rate came from, e.g. lvbank or ecb.
+	Source string
+}
+
+// Series identifies the rates of a currency against a base of one rate type.
+type Series struct {
+	Base string
+	Code string
+	Type RateType
+}
+
+// ReferenceSeries is the series of the ECB reference rate of the currency against the euro.
+func ReferenceSeries(code string) Series {
+	return Series{Base: DefaultBase, Code: code, Type: RateTypeReference}
+}
+
+func (r Rate) Series() Series {
+	return Series{Base: r.Base, Code: r.Code, Type: r.Type}
+}
+
+func (s Series) String() string {
+	return fmt.Sprintf("%s/%s %s", s.Base, s.Code, s.Type)
 }
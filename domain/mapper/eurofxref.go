@@ -0,0 +1,102 @@
+package mapper
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/zemzale/backscreen-home/domain/ecb"
+	"github.com/zemzale/backscreen-home/domain/entity"
+)
+
+// EurofxrefEnvelope is the shape of the ECB eurofxref feeds, e.g. eurofxref-daily.xml.
+// Each day is a Cube with a time attribute holding a Cube per currency.
+type EurofxrefEnvelope struct {
+	XMLName xml.Name       `xml:"Envelope"`
+	Days    []EurofxrefDay `xml:"Cube>Cube"`
+}
+
+type EurofxrefDay struct {
+	Time  string          `xml:"time,attr"`
+	Rates []EurofxrefRate `xml:"Cube"`
+}
+
+type EurofxrefRate struct {
+	Currency string `xml:"currency,attr"`
+	Rate     string `xml:"rate,attr"`
+}
+
+// FeedFromEurofxref parses an ECB eurofxref feed. Like FeedFromXML, the days that can't be
+// parsed are returned next to the feed. The feed has no publication times, so rates are
+// published at the usual ECB publication hour of their reference date.
+func FeedFromEurofxref(reader io.Reader) (feed entity.Feed, itemErrs []*ParseError, err error) {
+	var envelope EurofxrefEnvelope
+	if err := xml.NewDecoder(reader).Decode(&envelope); err != nil {
+		return entity.Feed{}, nil, err
+	}
+
+	if len(envelope.Days) == 0 {
+		return entity.Feed{}, nil, ErrNoRates
+	}
+
+	for index, day := range envelope.Days {
+		item, itemErr := itemFromEurofxrefDay(index, day)
+		if itemErr != nil {
+			itemErrs = append(itemErrs, itemErr)
+			continue
+		}
+		feed.Items = append(feed.Items, item)
+	}
+
+	return feed, itemErrs, nil
+}
+
+func itemFromEurofxrefDay(index int, day EurofxrefDay) (entity.FeedItem, *ParseError) {
+	guid := strings.TrimSpace(day.Time)
+	fail := func(token int, value string, err error) *ParseError {
+		return &ParseError{Item: index, GUID: guid, Token: token, Value: value, Err: err}
+	}
+
+	date, err := time.Parse(time.DateOnly, guid)
+	if err != nil {
+		return entity.FeedItem{}, fail(-1, day.Time, ErrInvalidDate)
+	}
+	if len(day.Rates) == 0 {
+		return entity.FeedItem{}, fail(-1, day.Time, ErrNoRates)
+	}
+
+	publishedAt := time.Date(date.Year(), date.Month(), date.Day(), ecb.PublicationHour, 0, 0, 0, ecb.Location).UTC()
+
+	item := entity.FeedItem{
+		GUID:        guid,
+		PublishedAt: publishedAt,
+		Rates:       make([]entity.Rate, 0, len(day.Rates)),
+	}
+
+	// Raw uses the same "<code> <value>" pairs as the bank.lv descriptions, so imports look alike.
+	raw := make([]string, 0, len(day.Rates))
+	for i, rate := range day.Rates {
+		code, value := strings.TrimSpace(rate.Currency), strings.TrimSpace(rate.Rate)
+		if !IsCurrencyCode(code) {
+			return entity.FeedItem{}, fail(i, rate.Currency, ErrInvalidCurrency)
+		}
+		if !isDecimal(value) {
+			return entity.FeedItem{}, fail(i, rate.Rate, ErrInvalidValue)
+		}
+
+		item.Rates = append(item.Rates, entity.Rate{
+			PublishedAt: publishedAt,
+			Date:        date,
+			Base:        entity.DefaultBase,
+			Code:        code,
+			Value:       value,
+			Type:        entity.RateTypeReference,
+		})
+		raw = append(raw, fmt.Sprintf("%s %s", code, value))
+	}
+	item.Raw = strings.Join(raw, " ")
+
+	return item, nil
+}
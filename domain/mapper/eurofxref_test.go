@@ -0,0 +1,69 @@
+package mapper
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zemzale/backscreen-home/domain/entity"
+)
+
+func TestFeedFromEurofxref(t *testing.T) {
+	xmlFile, err := os.Open("testdata/eurofxref.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer xmlFile.Close()
+
+	feed, itemErrs, err := FeedFromEurofxref(xmlFile)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(itemErrs) != 0 {
+		t.Errorf("Expected no item errors, got %v", itemErrs)
+	}
+	if len(feed.Items) != 2 {
+		t.Fatalf("Expected 2 days, got %d", len(feed.Items))
+	}
+
+	first := feed.Items[0]
+	if first.GUID != "2025-10-10" || first.Raw != "USD 1.1568 JPY 176.46 BGN 1.9558 GBP 0.87090" {
+		t.Errorf("Expected the 2025-10-10 day, got %+v", first)
+	}
+
+	// 16:00 in Frankfurt is 14:00 UTC in summer.
+	publishedAt := time.Date(2025, time.October, 10, 14, 0, 0, 0, time.UTC)
+	date := time.Date(2025, time.October, 10, 0, 0, 0, 0, time.UTC)
+	usd := first.Rates[0]
+	if usd.Code != "USD" || usd.Value != "1.1568" || !usd.PublishedAt.Equal(publishedAt) || !usd.Date.Equal(date) {
+		t.Errorf("Expected USD 1.1568 published at %s on %s, got %+v", publishedAt, date, usd)
+	}
+	if usd.Series() != entity.ReferenceSeries("USD") {
+		t.Errorf("Expected the EUR reference series, got %s", usd.Series())
+	}
+}
+
+func TestFeedFromEurofxrefErrors(t *testing.T) {
+	feed := `<Envelope><Cube>
+		<Cube time="2025-10-10"><Cube currency="USD" rate="1.1568"/></Cube>
+		<Cube time="yesterday"><Cube currency="USD" rate="1.1611"/></Cube>
+		<Cube time="2025-10-08"><Cube currency="USD" rate="-1"/></Cube>
+	</Cube></Envelope>`
+
+	got, itemErrs, err := FeedFromEurofxref(strings.NewReader(feed))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(got.Items) != 1 {
+		t.Errorf("Expected 1 valid day, got %d", len(got.Items))
+	}
+	if len(itemErrs) != 2 || !errors.Is(itemErrs[0], ErrInvalidDate) || !errors.Is(itemErrs[1], ErrInvalidValue) {
+		t.Errorf("Expected a date and a value error, got %v", itemErrs)
+	}
+
+	if _, _, err := FeedFromEurofxref(strings.NewReader(`<Envelope><Cube></Cube></Envelope>`)); !errors.Is(err, ErrNoRates) {
+		t.Errorf("Expected %v, got %v", ErrNoRates, err)
+	}
+}
@@ -3,73 +3,216 @@ package mapper
 import (
 	"encoding/xml"
 	"errors"
+	"fmt"
 	"io"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/zemzale/backscreen-home/domain/ecb"
 	"github.com/zemzale/backscreen-home/domain/entity"
 )
 
+var (
+	ErrNoRates          = errors.New("no rates found")
+	ErrInvalidDate      = errors.New("failed to parse publication date")
+	ErrInvalidFormat    = errors.New("invalid rate format")
+	ErrInvalidCurrency  = errors.New("invalid currency code")
+	ErrInvalidValue     = errors.New("invalid value")
+	ErrMissingRateValue = errors.New("currency code without a value")
+)
+
+// ParseError points at the part of the feed that could not be parsed.
+type ParseError struct {
+	// Item is the zero based index of the item in the channel.
+	Item int
+	// GUID of the item, empty when the feed doesn't have one.
+	GUID string
+	// Token is the zero based index of the whitespace separated token in the item description,
+	// -1 when the error is not about a single token.
+	Token int
+	// Value is the offending text.
+	Value string
+	Err   error
+}
+
+func (e *ParseError) Error() string {
+	location := fmt.Sprintf("item %d", e.Item)
+	if e.GUID != "" {
+		location += fmt.Sprintf(" (%s)", e.GUID)
+	}
+	if e.Token >= 0 {
+		location += fmt.Sprintf(", token %d", e.Token)
+	}
+	return fmt.Sprintf("%s: %s: %q", location, e.Err, e.Value)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// LVBankRSSRateFeed is the shape of the whole feed document, StreamRates reads it item by item.
 type LVBankRSSRateFeed struct {
 	XMLName xml.Name `xml:"rss"`
 	Channel Channel  `xml:"channel"`
 }
 
 type Channel struct {
-	XMLName xml.Name `xml:"channel"`
-	Title   string   `xml:"title"`
-	Item    []Item   `xml:"item"`
+	XMLName       xml.Name `xml:"channel"`
+	Title         string   `xml:"title"`
+	LastBuildDate string   `xml:"lastBuildDate"`
+	// TTL is in minutes.
+	TTL  string `xml:"ttl"`
+	Item []Item `xml:"item"`
 }
 
 type Item struct {
 	XMLName         xml.Name `xml:"item"`
 	Title           string   `xml:"title"`
+	GUID            string   `xml:"guid"`
 	Description     string   `xml:"description"`
 	PublicationDate string   `xml:"pubDate"`
 }
 
+// RatesFromXML parses the feed and fails on the first item that can't be parsed.
 func RatesFromXML(reader io.Reader) ([]entity.Rate, error) {
-	var feed LVBankRSSRateFeed
-	if err := xml.NewDecoder(reader).Decode(&feed); err != nil {
-		return nil, err
-	}
-
-	if len(feed.Channel.Item) == 0 {
-		return nil, errors.New("no rates found")
+	var rates []entity.Rate
+	for rate, err := range StreamRates(reader) {
+		if err != nil {
+			return nil, err
+		}
+		rates = append(rates, rate)
 	}
 
-	var rates []entity.Rate
+	return rates, nil
+}
 
-	for _, item := range feed.Channel.Item {
-		publishedAt, err := time.Parse(time.RFC1123Z, item.PublicationDate)
+// RatesFromXMLLenient skips the items that can't be parsed instead of failing the whole feed.
+// The errors of the skipped items are returned next to the rates, err is only set when
+// the feed itself can't be read.
+func RatesFromXMLLenient(reader io.Reader) (rates []entity.Rate, itemErrs []*ParseError, err error) {
+	for rate, err := range StreamRates(reader) {
+		var itemErr *ParseError
+		if errors.As(err, &itemErr) {
+			itemErrs = append(itemErrs, itemErr)
+			continue
+		}
 		if err != nil {
-			return nil, errors.New("failed to parse publication date")
+			return nil, nil, err
 		}
+		rates = append(rates, rate)
+	}
 
-		rateValues := strings.Split(strings.TrimSpace(item.Description), " ")
-		if len(rateValues) < 2 {
-			return nil, errors.New("invalid rate format")
+	return rates, itemErrs, nil
+}
+
+// FeedFromXML parses the feed with its metadata. Like RatesFromXMLLenient, the items that can't
+// be parsed are returned next to the feed. A lastBuildDate or ttl that can't be parsed is
+// treated as missing, they only tune when the feed is fetched.
+func FeedFromXML(reader io.Reader) (feed entity.Feed, itemErrs []*ParseError, err error) {
+	var channel Channel
+	err = decodeItems(reader, &channel, func(index int, item Item) bool {
+		rates, itemErr := ratesFromItem(index, item)
+		if itemErr != nil {
+			itemErrs = append(itemErrs, itemErr)
+			return true
 		}
 
-		for i := 0; i < len(rateValues); i += 2 {
-			currencyCode := rateValues[i]
-			value := rateValues[i+1]
+		feed.Items = append(feed.Items, entity.FeedItem{
+			GUID:        strings.TrimSpace(item.GUID),
+			PublishedAt: rates[0].PublishedAt,
+			Raw:         strings.TrimSpace(item.Description),
+			Rates:       rates,
+		})
+		return true
+	})
+	if err != nil {
+		return entity.Feed{}, nil, err
+	}
+
+	if buildDate, err := time.Parse(time.RFC1123Z, strings.TrimSpace(channel.LastBuildDate)); err == nil {
+		feed.BuildDate = buildDate.UTC()
+	}
+	if ttl, err := strconv.Atoi(strings.TrimSpace(channel.TTL)); err == nil && ttl > 0 {
+		feed.TTL = time.Duration(ttl) * time.Minute
+	}
+
+	return feed, itemErrs, nil
+}
+
+// ratesFromItem parses the rates of the item at index. The description is a list of
+// currency code and value pairs separated by any whitespace.
+func ratesFromItem(index int, item Item) ([]entity.Rate, *ParseError) {
+	guid := strings.TrimSpace(item.GUID)
+	fail := func(token int, value string, err error) *ParseError {
+		return &ParseError{Item: index, GUID: guid, Token: token, Value: value, Err: err}
+	}
+
+	publishedAt, err := time.Parse(time.RFC1123Z, strings.TrimSpace(item.PublicationDate))
+	if err != nil {
+		return nil, fail(-1, item.PublicationDate, ErrInvalidDate)
+	}
 
-			if len(currencyCode) != 3 {
-				return nil, errors.New("invalid currency code")
-			}
+	tokens := strings.Fields(item.Description)
+	if len(tokens) == 0 {
+		return nil, fail(-1, item.Description, ErrInvalidFormat)
+	}
 
-			if len(value) == 0 {
-				return nil, errors.New("invalid value")
-			}
+	rates := make([]entity.Rate, 0, len(tokens)/2)
+	for i := 0; i < len(tokens); i += 2 {
+		code := tokens[i]
+		if !IsCurrencyCode(code) {
+			return nil, fail(i, code, ErrInvalidCurrency)
+		}
 
-			rates = append(rates, entity.Rate{
-				PublishedAt: publishedAt,
-				Code:        currencyCode,
-				Value:       value,
-			})
+		if i+1 >= len(tokens) {
+			return nil, fail(i, code, ErrMissingRateValue)
 		}
+
+		value := tokens[i+1]
+		if !isDecimal(value) {
+			return nil, fail(i+1, value, ErrInvalidValue)
+		}
+
+		rates = append(rates, entity.Rate{
+			PublishedAt: publishedAt.UTC(),
+			Date:        ecb.ReferenceDate(publishedAt),
+			Base:        entity.DefaultBase,
+			Code:        code,
+			Value:       value,
+			Type:        entity.RateTypeReference,
+		})
 	}
 
 	return rates, nil
 }
+
+// IsCurrencyCode reports whether s looks like an ISO 4217 code, e.g. USD.
+func IsCurrencyCode(s string) bool {
+	if len(s) != 3 {
+		return false
+	}
+	for _, c := range []byte(s) {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// isDecimal reports whether s is a plain unsigned decimal number like 1.15680000.
+// Values are stored as text, so exponents and signs are not accepted.
+func isDecimal(s string) bool {
+	digits, dot := 0, false
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c >= '0' && c <= '9':
+			digits++
+		case c == '.' && !dot && digits > 0 && i < len(s)-1:
+			dot = true
+		default:
+			return false
+		}
+	}
+	return digits > 0
+}
@@ -1,9 +1,12 @@
 package mapper
 
 import (
+	"bytes"
 	"cmp"
+	"errors"
 	"os"
 	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -22,13 +25,17 @@ func TestRateFromXML(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	// Both publications are at 03:00 EEST, they are stored as midnight UTC of the reference date.
+	oct10 := time.Date(2025, time.October, 10, 0, 0, 0, 0, time.UTC)
+	oct13 := time.Date(2025, time.October, 13, 0, 0, 0, 0, time.UTC)
+
 	expectedRates := []entity.Rate{
-		{PublishedAt: time.Date(2025, time.October, 10, 3, 0, 0, 0, time.FixedZone("EEST", 3*60*60)), Code: "AUD", Value: "1.76500000"},
-		{PublishedAt: time.Date(2025, time.October, 10, 3, 0, 0, 0, time.FixedZone("EEST", 3*60*60)), Code: "BGN", Value: "1.95580000"},
-		{PublishedAt: time.Date(2025, time.October, 10, 3, 0, 0, 0, time.FixedZone("EEST", 3*60*60)), Code: "BRL", Value: "6.20820000"},
-		{PublishedAt: time.Date(2025, time.October, 13, 3, 0, 0, 0, time.FixedZone("EEST", 3*60*60)), Code: "AUD", Value: "1.77750000"},
-		{PublishedAt: time.Date(2025, time.October, 13, 3, 0, 0, 0, time.FixedZone("EEST", 3*60*60)), Code: "BGN", Value: "1.95580000"},
-		{PublishedAt: time.Date(2025, time.October, 13, 3, 0, 0, 0, time.FixedZone("EEST", 3*60*60)), Code: "BRL", Value: "6.33440000"},
+		{PublishedAt: oct10, Date: oct10, Code: "AUD", Value: "1.76500000"},
+		{PublishedAt: oct10, Date: oct10, Code: "BGN", Value: "1.95580000"},
+		{PublishedAt: oct10, Date: oct10, Code: "BRL", Value: "6.20820000"},
+		{PublishedAt: oct13, Date: oct13, Code: "AUD", Value: "1.77750000"},
+		{PublishedAt: oct13, Date: oct13, Code: "BGN", Value: "1.95580000"},
+		{PublishedAt: oct13, Date: oct13, Code: "BRL", Value: "6.33440000"},
 	}
 
 	if len(rates) != len(expectedRates) {
@@ -56,8 +63,235 @@ func TestRateFromXML(t *testing.T) {
 		if got.Value != want.Value {
 			t.Errorf("Expected rate %d to have value %s, got %s", i, want.Value, got.Value)
 		}
-		if got.PublishedAt.Compare(want.PublishedAt) != 0 {
+		if !got.PublishedAt.Equal(want.PublishedAt) || got.PublishedAt.Location() != time.UTC {
 			t.Errorf("Expected rate %d to have published at %s, got %s", i, want.PublishedAt, got.PublishedAt)
 		}
+		if !got.Date.Equal(want.Date) {
+			t.Errorf("Expected rate %d to have date %s, got %s", i, want.Date, got.Date)
+		}
+	}
+}
+
+func TestRateFromXMLErrorLocation(t *testing.T) {
+	feed := `<rss><channel>
+		<item><description>AUD 1.765 BGN 1.955</description><pubDate>Fri, 10 Oct 2025 03:00:00 +0300</pubDate></item>
+		<item><description>AUD 1.777 BGN</description><pubDate>Mon, 13 Oct 2025 03:00:00 +0300</pubDate></item>
+	</channel></rss>`
+
+	_, err := RatesFromXML(strings.NewReader(feed))
+
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("Expected a ParseError, got %v", err)
+	}
+	if !errors.Is(err, ErrMissingRateValue) {
+		t.Errorf("Expected ErrMissingRateValue, got %v", parseErr.Err)
+	}
+	if parseErr.Item != 1 || parseErr.Token != 2 || parseErr.Value != "BGN" {
+		t.Errorf("Expected item 1, token 2 and value BGN, got item %d, token %d and value %q", parseErr.Item, parseErr.Token, parseErr.Value)
+	}
+}
+
+func TestRateFromXMLWhitespace(t *testing.T) {
+	feed := `<rss><channel><item>
+		<guid>https://www.bank.lv/#10.10</guid>
+		<description><![CDATA[
+			AUD   1.765
+			BGN	1.955 ]]></description>
+		<pubDate> Fri, 10 Oct 2025 03:00:00 +0300 </pubDate>
+	</item></channel></rss>`
+
+	rates, err := RatesFromXML(strings.NewReader(feed))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(rates) != 2 || rates[0].Code != "AUD" || rates[1].Value != "1.955" {
+		t.Errorf("Expected AUD and BGN rates, got %v", rates)
+	}
+}
+
+func TestRatesFromXMLLenient(t *testing.T) {
+	feed := `<rss><channel>
+		<item><guid>a</guid><description>AUD 1.765 BGN 1.955</description><pubDate>Fri, 10 Oct 2025 03:00:00 +0300</pubDate></item>
+		<item><guid>b</guid><description>AUD 1.777 BGN</description><pubDate>Mon, 13 Oct 2025 03:00:00 +0300</pubDate></item>
+		<item><guid>c</guid><description>AUD -1 BGN 1.955</description><pubDate>Tue, 14 Oct 2025 03:00:00 +0300</pubDate></item>
+		<item><guid>d</guid><description>AUD 1.790</description><pubDate>yesterday</pubDate></item>
+		<item><guid>e</guid><description>usd 1.155</description><pubDate>Wed, 15 Oct 2025 03:00:00 +0300</pubDate></item>
+	</channel></rss>`
+
+	rates, itemErrs, err := RatesFromXMLLenient(strings.NewReader(feed))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(rates) != 2 {
+		t.Errorf("Expected the 2 rates of the first item, got %d", len(rates))
+	}
+
+	expected := []struct {
+		guid  string
+		token int
+		value string
+		err   error
+	}{
+		{guid: "b", token: 2, value: "BGN", err: ErrMissingRateValue},
+		{guid: "c", token: 1, value: "-1", err: ErrInvalidValue},
+		{guid: "d", token: -1, value: "yesterday", err: ErrInvalidDate},
+		{guid: "e", token: 0, value: "usd", err: ErrInvalidCurrency},
+	}
+
+	if len(itemErrs) != len(expected) {
+		t.Fatalf("Expected %d item errors, got %d: %v", len(expected), len(itemErrs), itemErrs)
+	}
+
+	for i, want := range expected {
+		got := itemErrs[i]
+		if got.Item != i+1 || got.GUID != want.guid || got.Token != want.token || got.Value != want.value || !errors.Is(got, want.err) {
+			t.Errorf("Expected item %d (%s) token %d %q: %v, got %v", i+1, want.guid, want.token, want.value, want.err, got)
+		}
+	}
+
+	if _, err := RatesFromXML(strings.NewReader(feed)); !errors.Is(err, ErrMissingRateValue) {
+		t.Errorf("Expected strict parsing to fail on the first bad item, got %v", err)
+	}
+}
+
+func TestFeedFromXML(t *testing.T) {
+	xmlFile, err := os.Open("../../testdata/ecb_rss.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer xmlFile.Close()
+
+	feed, itemErrs, err := FeedFromXML(xmlFile)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(itemErrs) != 0 {
+		t.Errorf("Expected no item errors, got %v", itemErrs)
+	}
+
+	buildDate := time.Date(2025, time.October, 15, 12, 28, 44, 0, time.UTC)
+	if !feed.BuildDate.Equal(buildDate) {
+		t.Errorf("Expected build date %s, got %s", buildDate, feed.BuildDate)
+	}
+	if feed.TTL != 5*time.Minute {
+		t.Errorf("Expected TTL 5m, got %s", feed.TTL)
+	}
+
+	if len(feed.Items) == 0 {
+		t.Fatal("Expected items, got none")
+	}
+	first := feed.Items[0]
+	if first.GUID != "https://www.bank.lv/#10.10" {
+		t.Errorf("Expected guid https://www.bank.lv/#10.10, got %q", first.GUID)
+	}
+	if !strings.HasPrefix(first.Raw, "AUD 1.76500000 BGN") {
+		t.Errorf("Expected the raw description, got %q", first.Raw)
+	}
+	if len(first.Rates) != 30 || !first.PublishedAt.Equal(first.Rates[0].PublishedAt) {
+		t.Errorf("Expected 30 rates published at %s, got %d", first.PublishedAt, len(first.Rates))
+	}
+}
+
+func TestFeedFromXMLMissingMetadata(t *testing.T) {
+	feed := `<rss><channel>
+		<lastBuildDate>soon</lastBuildDate>
+		<item><description>AUD 1.765</description><pubDate>Fri, 10 Oct 2025 03:00:00 +0300</pubDate></item>
+	</channel></rss>`
+
+	got, _, err := FeedFromXML(strings.NewReader(feed))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !got.BuildDate.IsZero() || got.TTL != 0 {
+		t.Errorf("Expected no build date and TTL, got %s and %s", got.BuildDate, got.TTL)
+	}
+	if len(got.Items) != 1 || got.Items[0].GUID != "" {
+		t.Errorf("Expected 1 item without guid, got %+v", got.Items)
+	}
+}
+
+func FuzzRatesFromXML(f *testing.F) {
+	for _, path := range []string{"testdata/ecb.xml", "../../testdata/ecb_rss.xml"} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(data)
+	}
+	f.Add([]byte(`<rss><channel><item><description>AUD 1 BGN</description><pubDate>Fri, 10 Oct 2025 03:00:00 +0300</pubDate></item></channel></rss>`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		strictRates, strictErr := RatesFromXML(bytes.NewReader(data))
+		rates, itemErrs, err := RatesFromXMLLenient(bytes.NewReader(data))
+
+		if (strictErr == nil) != (err == nil && len(itemErrs) == 0) {
+			t.Fatalf("Expected strict and lenient parsing to agree, got %v and %v, %v", strictErr, err, itemErrs)
+		}
+		if strictErr == nil && len(strictRates) != len(rates) {
+			t.Fatalf("Expected the same rates in both modes, got %d and %d", len(strictRates), len(rates))
+		}
+
+		for _, rate := range rates {
+			if !IsCurrencyCode(rate.Code) || !isDecimal(rate.Value) || rate.PublishedAt.IsZero() {
+				t.Fatalf("Expected only valid rates, got %+v", rate)
+			}
+		}
+		for _, itemErr := range itemErrs {
+			if itemErr.Err == nil {
+				t.Fatalf("Expected item errors to have a cause, got %+v", itemErr)
+			}
+		}
+	})
+}
+
+func TestStreamRates(t *testing.T) {
+	feed := `<?xml version="1.0"?><rss><channel>
+		<title>Exchange Rates</title>
+		<item><description>AUD 1.765 BGN 1.955</description><pubDate>Fri, 10 Oct 2025 03:00:00 +0300</pubDate></item>
+		<item><description>AUD</description><pubDate>Mon, 13 Oct 2025 03:00:00 +0300</pubDate></item>
+		<item><description>AUD 1.790 BGN 1.955</description><pubDate>Tue, 14 Oct 2025 03:00:00 +0300</pubDate></item>
+	</channel></rss>`
+
+	var (
+		codes    []string
+		itemErrs int
+	)
+	for rate, err := range StreamRates(strings.NewReader(feed)) {
+		if err != nil {
+			itemErrs++
+			continue
+		}
+		codes = append(codes, rate.Code)
+	}
+
+	if strings.Join(codes, ",") != "AUD,BGN,AUD,BGN" {
+		t.Errorf("Expected AUD,BGN,AUD,BGN, got %s", strings.Join(codes, ","))
+	}
+	if itemErrs != 1 {
+		t.Errorf("Expected 1 item error, got %d", itemErrs)
+	}
+
+	count := 0
+	for range StreamRates(strings.NewReader(feed)) {
+		count++
+		break
+	}
+	if count != 1 {
+		t.Errorf("Expected the stream to stop after 1 rate, got %d", count)
+	}
+
+	for name, doc := range map[string]string{
+		"empty channel": `<rss><channel></channel></rss>`,
+		"other root":    `<feed><item/></feed>`,
+		"truncated":     `<rss><channel><item><description>AUD 1`,
+	} {
+		var lastErr error
+		for _, err := range StreamRates(strings.NewReader(doc)) {
+			lastErr = err
+		}
+		if lastErr == nil {
+			t.Errorf("Expected an error for %s, got nil", name)
+		}
 	}
 }
@@ -0,0 +1,113 @@
+package mapper
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+
+	"github.com/zemzale/backscreen-home/domain/entity"
+)
+
+// StreamRates decodes the feed one item at a time, so only a single item is held in memory
+// no matter how large the feed is.
+//
+// An item that can't be parsed yields a *ParseError and the stream continues with the next
+// item, stop ranging to fail on the first one. Errors reading the document end the stream.
+func StreamRates(reader io.Reader) iter.Seq2[entity.Rate, error] {
+	return func(yield func(entity.Rate, error) bool) {
+		err := decodeItems(reader, nil, func(index int, item Item) bool {
+			rates, itemErr := ratesFromItem(index, item)
+			if itemErr != nil {
+				return yield(entity.Rate{}, itemErr)
+			}
+
+			for _, rate := range rates {
+				if !yield(rate, nil) {
+					return false
+				}
+			}
+			return true
+		})
+		if err != nil {
+			yield(entity.Rate{}, err)
+		}
+	}
+}
+
+// decodeItems calls fn with every item of rss > channel until it returns false. When channel
+// is not nil, the channel metadata is decoded into it as well. Metadata listed after the
+// first item is only set once fn saw the items before it.
+func decodeItems(reader io.Reader, channel *Channel, fn func(index int, item Item) bool) error {
+	decoder := xml.NewDecoder(reader)
+
+	// path holds the names of the open elements.
+	var path []string
+	items := 0
+
+tokens:
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			// The decoder reports an unclosed element as a syntax error, so this is an empty document.
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		switch el := token.(type) {
+		case xml.StartElement:
+			if len(path) == 0 && el.Name.Local != "rss" {
+				return fmt.Errorf("expected element type <rss> but have <%s>", el.Name.Local)
+			}
+
+			if len(path) == 2 && path[1] == "channel" {
+				var field *string
+				switch el.Name.Local {
+				case "item":
+					var item Item
+					if err := decoder.DecodeElement(&item, &el); err != nil {
+						return err
+					}
+
+					index := items
+					items++
+					if !fn(index, item) {
+						return nil
+					}
+					continue
+				case "lastBuildDate":
+					if channel != nil {
+						field = &channel.LastBuildDate
+					}
+				case "ttl":
+					if channel != nil {
+						field = &channel.TTL
+					}
+				}
+
+				if field != nil {
+					if err := decoder.DecodeElement(field, &el); err != nil {
+						return err
+					}
+					continue
+				}
+			}
+
+			path = append(path, el.Name.Local)
+		case xml.EndElement:
+			path = path[:len(path)-1]
+			// Anything after the root element is ignored, like when decoding the whole document.
+			if len(path) == 0 {
+				break tokens
+			}
+		}
+	}
+
+	if items == 0 {
+		return ErrNoRates
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package apikeys
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/zemzale/backscreen-home/domain/entity"
+	"github.com/zemzale/backscreen-home/storage"
+)
+
+// keyPrefix marks tokens issued by this service, which makes leaked keys easy to grep for.
+const keyPrefix = "bsh_"
+
+var ErrInvalidKey = errors.New("invalid api key")
+
+type Usecase struct {
+	store *storage.Client
+}
+
+func New(store *storage.Client) *Usecase {
+	return &Usecase{
+		store: store,
+	}
+}
+
+// Create issues a new key with the given scopes. The returned token is the only time
+// the plain key is available, only its hash is stored.
+func (u *Usecase) Create(ctx context.Context, name string, scopes []entity.Scope) (string, entity.APIKey, error) {
+	logger := slog.With(slog.String("component", "apikeys"))
+
+	if len(scopes) == 0 {
+		return "", entity.APIKey{}, errors.New("at least one scope is required")
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", entity.APIKey{}, fmt.Errorf("failed to generate key: %w", err)
+	}
+
+	token := keyPrefix + hex.EncodeToString(secret)
+
+	key, err := u.store.StoreAPIKey(ctx, entity.APIKey{
+		Name:   name,
+		Prefix: token[:len(keyPrefix)+8],
+		Scopes: scopes,
+	}, hash(token))
+	if err != nil {
+		return "", entity.APIKey{}, fmt.Errorf("failed to store key: %w", err)
+	}
+
+	logger.InfoContext(ctx, "Created API key", slog.Int("id", key.ID), slog.String("prefix", key.Prefix))
+
+	return token, key, nil
+}
+
+func (u *Usecase) List(ctx context.Context) ([]entity.APIKey, error) {
+	return u.store.ListAPIKeys(ctx)
+}
+
+func (u *Usecase) Revoke(ctx context.Context, id int) error {
+	logger := slog.With(slog.String("component", "apikeys"))
+
+	if err := u.store.RevokeAPIKey(ctx, id); err != nil {
+		return fmt.Errorf("failed to revoke key %d: %w", id, err)
+	}
+
+	logger.InfoContext(ctx, "Revoked API key", slog.Int("id", id))
+
+	return nil
+}
+
+// Authenticate resolves a plain token to its key. Unknown and revoked keys return ErrInvalidKey.
+func (u *Usecase) Authenticate(ctx context.Context, token string) (entity.APIKey, error) {
+	if !strings.HasPrefix(token, keyPrefix) {
+		return entity.APIKey{}, ErrInvalidKey
+	}
+
+	key, err := u.store.GetAPIKeyByHash(ctx, hash(token))
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return entity.APIKey{}, ErrInvalidKey
+		}
+		return entity.APIKey{}, err
+	}
+
+	if key.Revoked() {
+		return entity.APIKey{}, ErrInvalidKey
+	}
+
+	return key, nil
+}
+
+func hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
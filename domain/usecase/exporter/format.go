@@ -0,0 +1,165 @@
+package exporter
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/zemzale/backscreen-home/domain/entity"
+)
+
+type Format string
+
+const (
+	FormatCSV     Format = "csv"
+	FormatNDJSON  Format = "ndjson"
+	FormatParquet Format = "parquet"
+)
+
+var Formats = []Format{FormatCSV, FormatNDJSON, FormatParquet}
+
+func ParseFormat(s string) (Format, error) {
+	for _, f := range Formats {
+		if string(f) == s {
+			return f, nil
+		}
+	}
+
+	return "", fmt.Errorf("unsupported export format %q", s)
+}
+
+type encoder interface {
+	Encode(rate entity.Rate) error
+	Close() error
+}
+
+func newEncoder(w io.Writer, format Format) (encoder, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVEncoder(w)
+	case FormatNDJSON:
+		return &ndjsonEncoder{enc: json.NewEncoder(w)}, nil
+	case FormatParquet:
+		return newParquetEncoder(w), nil
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+type csvEncoder struct {
+	w *csv.Writer
+}
+
+func newCSVEncoder(w io.Writer) (*csvEncoder, error) {
+	enc := &csvEncoder{w: csv.NewWriter(w)}
+	// base and type come last, so readers of the older columns keep working.
+	if err := enc.w.Write([]string{"code", "value", "date", "published_at", "base", "type"}); err != nil {
+		return nil, err
+	}
+
+	return enc, nil
+}
+
+func (e *csvEncoder) Encode(rate entity.Rate) error {
+	return e.w.Write([]string{rate.Code, rate.Value, rate.Date.Format(time.DateOnly), rate.PublishedAt.UTC().Format(time.RFC3339), rate.Base, string(rate.Type)})
+}
+
+func (e *csvEncoder) Close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+type ndjsonRate struct {
+	Code        string    `json:"code"`
+	Value       string    `json:"value"`
+	Date        string    `json:"date"`
+	PublishedAt time.Time `json:"published_at"`
+	Base        string    `json:"base"`
+	Type        string    `json:"type"`
+}
+
+type ndjsonEncoder struct {
+	enc *json.Encoder
+}
+
+func (e *ndjsonEncoder) Encode(rate entity.Rate) error {
+	return e.enc.Encode(ndjsonRate{
+		Code:        rate.Code,
+		Value:       rate.Value,
+		Date:        rate.Date.Format(time.DateOnly),
+		PublishedAt: rate.PublishedAt.UTC(),
+		Base:        rate.Base,
+		Type:        string(rate.Type),
+	})
+}
+
+func (e *ndjsonEncoder) Close() error {
+	return nil
+}
+
+const secondsPerDay = 24 * 60 * 60
+
+type parquetRate struct {
+	Code  string `parquet:"code,dict"`
+	Value string `parquet:"value"`
+	// Date is stored as DATE, i.e. days since the Unix epoch.
+	Date        int32     `parquet:"date,date"`
+	PublishedAt time.Time `parquet:"published_at,timestamp(millisecond)"`
+	Base        string    `parquet:"base,dict"`
+	Type        string    `parquet:"type,dict"`
+}
+
+// parquetRowGroupSize bounds how many rows are buffered before a row group is flushed,
+// which keeps memory usage flat for large exports.
+const parquetRowGroupSize = 10_000
+
+type parquetEncoder struct {
+	w      *parquet.GenericWriter[parquetRate]
+	buffer []parquetRate
+}
+
+func newParquetEncoder(w io.Writer) *parquetEncoder {
+	return &parquetEncoder{
+		w:      parquet.NewGenericWriter[parquetRate](w, parquet.MaxRowsPerRowGroup(parquetRowGroupSize)),
+		buffer: make([]parquetRate, 0, parquetRowGroupSize),
+	}
+}
+
+func (e *parquetEncoder) Encode(rate entity.Rate) error {
+	e.buffer = append(e.buffer, parquetRate{
+		Code:        rate.Code,
+		Value:       rate.Value,
+		Date:        int32(rate.Date.Unix() / secondsPerDay),
+		PublishedAt: rate.PublishedAt.UTC(),
+		Base:        rate.Base,
+		Type:        string(rate.Type),
+	})
+
+	if len(e.buffer) < parquetRowGroupSize {
+		return nil
+	}
+
+	return e.flush()
+}
+
+func (e *parquetEncoder) flush() error {
+	if _, err := e.w.Write(e.buffer); err != nil {
+		return err
+	}
+	e.buffer = e.buffer[:0]
+
+	return e.w.Flush()
+}
+
+func (e *parquetEncoder) Close() error {
+	if len(e.buffer) > 0 {
+		if err := e.flush(); err != nil {
+			return err
+		}
+	}
+
+	return e.w.Close()
+}
@@ -0,0 +1,76 @@
+package exporter
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/zemzale/backscreen-home/domain/entity"
+)
+
+var testRates = []entity.Rate{
+	{PublishedAt: time.Date(2025, time.October, 10, 0, 0, 0, 0, time.UTC), Date: time.Date(2025, time.October, 10, 0, 0, 0, 0, time.UTC), Base: "EUR", Code: "AUD", Value: "1.76500000", Type: entity.RateTypeReference},
+	{PublishedAt: time.Date(2025, time.October, 13, 0, 0, 0, 0, time.UTC), Date: time.Date(2025, time.October, 13, 0, 0, 0, 0, time.UTC), Base: "EUR", Code: "AUD", Value: "1.77750000", Type: entity.RateTypeReference},
+}
+
+func encode(t *testing.T, format Format) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	enc, err := newEncoder(&buf, format)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, rate := range testRates {
+		if err := enc.Encode(rate); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := enc.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestCSVEncoder(t *testing.T) {
+	want := "code,value,date,published_at,base,type\n" +
+		"AUD,1.76500000,2025-10-10,2025-10-10T00:00:00Z,EUR,reference\n" +
+		"AUD,1.77750000,2025-10-13,2025-10-13T00:00:00Z,EUR,reference\n"
+
+	if got := string(encode(t, FormatCSV)); got != want {
+		t.Errorf("Expected CSV output %q, got %q", want, got)
+	}
+}
+
+func TestNDJSONEncoder(t *testing.T) {
+	want := `{"code":"AUD","value":"1.76500000","date":"2025-10-10","published_at":"2025-10-10T00:00:00Z","base":"EUR","type":"reference"}` + "\n" +
+		`{"code":"AUD","value":"1.77750000","date":"2025-10-13","published_at":"2025-10-13T00:00:00Z","base":"EUR","type":"reference"}` + "\n"
+
+	if got := string(encode(t, FormatNDJSON)); got != want {
+		t.Errorf("Expected NDJSON output %q, got %q", want, got)
+	}
+}
+
+func TestParquetEncoder(t *testing.T) {
+	data := encode(t, FormatParquet)
+
+	rows, err := parquet.Read[parquetRate](bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rows) != len(testRates) {
+		t.Fatalf("Expected %d rows, got %d", len(testRates), len(rows))
+	}
+
+	for i, row := range rows {
+		want := testRates[i]
+		if row.Code != want.Code || row.Value != want.Value || row.Base != want.Base || row.Type != string(want.Type) || !row.PublishedAt.Equal(want.PublishedAt) || !time.Unix(int64(row.Date)*secondsPerDay, 0).Equal(want.Date) {
+			t.Errorf("Expected row %d to be %+v, got %+v", i, want, row)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package exporter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/zemzale/backscreen-home/domain/entity"
+	"github.com/zemzale/backscreen-home/storage"
+)
+
+type Usecase struct {
+	store *storage.Client
+}
+
+func New(store *storage.Client) *Usecase {
+	return &Usecase{
+		store: store,
+	}
+}
+
+// Export writes all rates matching the filter to w in the given format.
+// Rates are streamed from the database, so the export size is not bound by memory.
+func (u *Usecase) Export(ctx context.Context, w io.Writer, format Format, filter storage.RateFilter) (int, error) {
+	logger := slog.With(slog.String("component", "export"), slog.String("format", string(format)))
+
+	enc, err := newEncoder(w, format)
+	if err != nil {
+		return 0, err
+	}
+
+	logger.DebugContext(ctx, "Exporting rates", slog.Any("filter", filter))
+
+	var count int
+	err = u.store.EachRate(ctx, filter, func(rate entity.Rate) error {
+		count++
+		return enc.Encode(rate)
+	})
+	if err != nil {
+		return count, fmt.Errorf("failed to export rates: %w", err)
+	}
+
+	if err := enc.Close(); err != nil {
+		return count, fmt.Errorf("failed to finish export: %w", err)
+	}
+
+	logger.DebugContext(ctx, "Exported rates", slog.Int("count", count))
+
+	return count, nil
+}
@@ -0,0 +1,68 @@
+package quarantine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/zemzale/backscreen-home/domain/entity"
+	"github.com/zemzale/backscreen-home/storage"
+)
+
+var (
+	ErrReviewerRequired = errors.New("a reviewer is required")
+	ErrReasonRequired   = errors.New("a reason is required")
+)
+
+type Usecase struct {
+	store *storage.Client
+}
+
+func New(store *storage.Client) *Usecase {
+	return &Usecase{
+		store: store,
+	}
+}
+
+func (u *Usecase) List(ctx context.Context, status entity.QuarantineStatus) ([]entity.QuarantinedRate, error) {
+	return u.store.ListQuarantinedRates(ctx, status)
+}
+
+// Approve stores the quarantined rate with the other rates.
+func (u *Usecase) Approve(ctx context.Context, id int, reviewer, reason string) (entity.QuarantinedRate, error) {
+	return u.review(ctx, id, entity.QuarantineApproved, reviewer, reason)
+}
+
+// Reject keeps the quarantined rate out of the stored rates for good.
+func (u *Usecase) Reject(ctx context.Context, id int, reviewer, reason string) (entity.QuarantinedRate, error) {
+	return u.review(ctx, id, entity.QuarantineRejected, reviewer, reason)
+}
+
+func (u *Usecase) review(ctx context.Context, id int, status entity.QuarantineStatus, reviewer, reason string) (entity.QuarantinedRate, error) {
+	logger := slog.With(slog.String("component", "quarantine"))
+
+	reviewer, reason = strings.TrimSpace(reviewer), strings.TrimSpace(reason)
+	if reviewer == "" {
+		return entity.QuarantinedRate{}, ErrReviewerRequired
+	}
+	if reason == "" {
+		return entity.QuarantinedRate{}, ErrReasonRequired
+	}
+
+	rate, err := u.store.ReviewQuarantinedRate(ctx, id, status, reviewer, reason)
+	if err != nil {
+		return rate, fmt.Errorf("failed to review quarantined rate %d: %w", id, err)
+	}
+
+	logger.InfoContext(ctx, "Reviewed quarantined rate",
+		slog.Int("id", id),
+		slog.String("status", string(status)),
+		slog.String("reviewer", reviewer),
+		slog.String("reason", reason),
+		slog.Any("rate", rate.Rate),
+	)
+
+	return rate, nil
+}
@@ -0,0 +1,170 @@
+package quarantine
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/zemzale/backscreen-home/domain/entity"
+	"github.com/zemzale/backscreen-home/storage"
+)
+
+func TestReviewRequiresReviewerAndReason(t *testing.T) {
+	// The checks run before the store is used, so none is needed.
+	u := New(nil)
+
+	if _, err := u.Approve(context.Background(), 1, " ", "looks right"); !errors.Is(err, ErrReviewerRequired) {
+		t.Errorf("Expected %v, got %v", ErrReviewerRequired, err)
+	}
+
+	if _, err := u.Reject(context.Background(), 1, "alice", ""); !errors.Is(err, ErrReasonRequired) {
+		t.Errorf("Expected %v, got %v", ErrReasonRequired, err)
+	}
+}
+
+var (
+	rateDate    = time.Date(2025, time.October, 10, 0, 0, 0, 0, time.UTC)
+	publishedAt = rateDate.Add(14 * time.Hour)
+)
+
+// quarantinedRow returns quarantined rate 12 as it is read back from the database.
+func quarantinedRow(status entity.QuarantineStatus) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{
+		"id", "code", "value", "rate_date", "published_at", "base", "rate_type", "source", "reason",
+		"status", "created_at", "reviewed_by", "review_reason", "reviewed_at",
+	})
+	if status == entity.QuarantinePending {
+		return rows.AddRow(12, "USD", "1.1568", rateDate, publishedAt, "EUR", "reference", "ecb", "changed by 12%",
+			string(status), publishedAt, nil, nil, nil)
+	}
+	return rows.AddRow(12, "USD", "1.1568", rateDate, publishedAt, "EUR", "reference", "ecb", "changed by 12%",
+		string(status), publishedAt, "alice", "ECB confirmed the rate", publishedAt.Add(time.Hour))
+}
+
+// newReviewStore returns a store backed by the mock that counts how often the stored rates change.
+func newReviewStore(t *testing.T) (*storage.Client, sqlmock.Sqlmock, *int) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	store := storage.New(sqlx.NewDb(db, "mysql"))
+	changes := 0
+	store.OnRatesChanged(func() { changes++ })
+
+	return store, mock, &changes
+}
+
+func TestApproveStoresRate(t *testing.T) {
+	store, mock, changes := newReviewStore(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("FROM quarantined_rates WHERE id = ? FOR UPDATE;")).WithArgs(12).
+		WillReturnRows(quarantinedRow(entity.QuarantinePending))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO rates")).
+		WithArgs("USD", "1.1568", "2025-10-10", publishedAt, "EUR", "reference", "ecb").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE quarantined_rates SET status = ?")).
+		WithArgs("approved", "alice", "ECB confirmed the rate", sqlmock.AnyArg(), 12).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM quarantined_rates WHERE id = ?;")).WithArgs(12).
+		WillReturnRows(quarantinedRow(entity.QuarantineApproved))
+	mock.ExpectCommit()
+
+	rate, err := New(store).Approve(context.Background(), 12, "alice", "ECB confirmed the rate")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if rate.Status != entity.QuarantineApproved || rate.ReviewedBy != "alice" {
+		t.Errorf("Expected the rate to be approved by alice, got %+v", rate)
+	}
+	if *changes != 1 {
+		t.Errorf("Expected the stored rates to change once, got %d", *changes)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestApproveKeepsStoredRate(t *testing.T) {
+	store, mock, changes := newReviewStore(t)
+
+	// Another rate of the date was stored in the meantime, so nothing is inserted.
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("FROM quarantined_rates WHERE id = ? FOR UPDATE;")).WithArgs(12).
+		WillReturnRows(quarantinedRow(entity.QuarantinePending))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO rates")).
+		WithArgs("USD", "1.1568", "2025-10-10", publishedAt, "EUR", "reference", "ecb").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	rate, err := New(store).Approve(context.Background(), 12, "alice", "ECB confirmed the rate")
+	if !errors.Is(err, storage.ErrDuplicate) {
+		t.Fatalf("Expected %v, got %v", storage.ErrDuplicate, err)
+	}
+	if rate.Status != entity.QuarantinePending {
+		t.Errorf("Expected the rate to stay pending, got %s", rate.Status)
+	}
+	if *changes != 0 {
+		t.Errorf("Expected the stored rates not to change, got %d changes", *changes)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRejectDoesNotStoreRate(t *testing.T) {
+	store, mock, changes := newReviewStore(t)
+
+	// The mock fails any statement that isn't expected, so the rate can't reach rates.
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("FROM quarantined_rates WHERE id = ? FOR UPDATE;")).WithArgs(12).
+		WillReturnRows(quarantinedRow(entity.QuarantinePending))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE quarantined_rates SET status = ?")).
+		WithArgs("rejected", "alice", "off by 1000x", sqlmock.AnyArg(), 12).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM quarantined_rates WHERE id = ?;")).WithArgs(12).
+		WillReturnRows(quarantinedRow(entity.QuarantineRejected))
+	mock.ExpectCommit()
+
+	rate, err := New(store).Reject(context.Background(), 12, "alice", "off by 1000x")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if rate.Status != entity.QuarantineRejected {
+		t.Errorf("Expected the rate to be rejected, got %s", rate.Status)
+	}
+	if *changes != 0 {
+		t.Errorf("Expected the stored rates not to change, got %d changes", *changes)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestReviewTwice(t *testing.T) {
+	store, mock, _ := newReviewStore(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("FROM quarantined_rates WHERE id = ? FOR UPDATE;")).WithArgs(12).
+		WillReturnRows(quarantinedRow(entity.QuarantineApproved))
+	mock.ExpectRollback()
+
+	rate, err := New(store).Reject(context.Background(), 12, "bob", "off by 1000x")
+	if !errors.Is(err, storage.ErrAlreadyReviewed) {
+		t.Fatalf("Expected %v, got %v", storage.ErrAlreadyReviewed, err)
+	}
+	if rate.Status != entity.QuarantineApproved || rate.ReviewedBy != "alice" {
+		t.Errorf("Expected the first review to be kept, got %+v", rate)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
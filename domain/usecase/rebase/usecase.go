@@ -0,0 +1,119 @@
+// Package rebase serves rates against another base currency, computed from the EUR rates.
+package rebase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zemzale/backscreen-home/domain/crossrate"
+	"github.com/zemzale/backscreen-home/domain/entity"
+	"github.com/zemzale/backscreen-home/storage"
+)
+
+// RateReader is implemented by both the storage client and its cache.
+type RateReader interface {
+	GetLatestRate(ctx context.Context, series entity.Series) (entity.Rate, error)
+	GetRates(ctx context.Context, series entity.Series) ([]entity.Rate, error)
+}
+
+type Usecase struct {
+	rates RateReader
+}
+
+func New(rates RateReader) *Usecase {
+	return &Usecase{rates: rates}
+}
+
+// Latest returns the newest rate of the series computed from the EUR rates of the same type.
+// It's the newest reference date that has EUR rates of both the currency and the base.
+// EUR itself can be requested against any other base. It returns storage.ErrNotFound when
+// there is no such date.
+func (u *Usecase) Latest(ctx context.Context, series entity.Series) (entity.Rate, error) {
+	code, base := pivotSeries(series)
+
+	baseRate, err := u.rates.GetLatestRate(ctx, base)
+	if err != nil {
+		return entity.Rate{}, err
+	}
+
+	if series.Code == entity.DefaultBase {
+		return crossrate.Pivot(baseRate)
+	}
+
+	rate, err := u.rates.GetLatestRate(ctx, code)
+	if err != nil {
+		return entity.Rate{}, err
+	}
+
+	if rate.Date.Equal(baseRate.Date) {
+		return crossrate.Rebase(rate, baseRate)
+	}
+
+	// One of them wasn't published on the newest date, so look for the newest one they share.
+	history, err := u.History(ctx, series)
+	if err != nil {
+		return entity.Rate{}, err
+	}
+	return history[0], nil
+}
+
+// History returns the rates of the series computed from the EUR rates of the same type, newest
+// first. Dates that only have a EUR rate of one of the currencies are skipped. It returns
+// storage.ErrNotFound when no date has both.
+func (u *Usecase) History(ctx context.Context, series entity.Series) ([]entity.Rate, error) {
+	code, base := pivotSeries(series)
+
+	baseRates, err := u.rates.GetRates(ctx, base)
+	if err != nil {
+		return nil, err
+	}
+
+	var rates []entity.Rate
+	if series.Code == entity.DefaultBase {
+		rates = make([]entity.Rate, 0, len(baseRates))
+		for _, baseRate := range baseRates {
+			rate, err := crossrate.Pivot(baseRate)
+			if err != nil {
+				return nil, err
+			}
+			rates = append(rates, rate)
+		}
+	} else {
+		codeRates, err := u.rates.GetRates(ctx, code)
+		if err != nil {
+			return nil, err
+		}
+
+		// time.Time keys also compare the location, so the dates are keyed by their instant.
+		byDate := make(map[int64]entity.Rate, len(baseRates))
+		for _, baseRate := range baseRates {
+			byDate[baseRate.Date.Unix()] = baseRate
+		}
+
+		for _, codeRate := range codeRates {
+			baseRate, ok := byDate[codeRate.Date.Unix()]
+			if !ok {
+				continue
+			}
+
+			rate, err := crossrate.Rebase(codeRate, baseRate)
+			if err != nil {
+				return nil, err
+			}
+			rates = append(rates, rate)
+		}
+	}
+
+	if len(rates) == 0 {
+		return nil, fmt.Errorf("no date has EUR rates of both %s and %s: %w", series.Code, series.Base, storage.ErrNotFound)
+	}
+
+	return rates, nil
+}
+
+// pivotSeries returns the EUR series of the currency and the base of series.
+func pivotSeries(series entity.Series) (code, base entity.Series) {
+	code = entity.Series{Base: entity.DefaultBase, Code: series.Code, Type: series.Type}
+	base = entity.Series{Base: entity.DefaultBase, Code: series.Base, Type: series.Type}
+	return code, base
+}
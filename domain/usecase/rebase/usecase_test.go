@@ -0,0 +1,105 @@
+package rebase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zemzale/backscreen-home/domain/entity"
+	"github.com/zemzale/backscreen-home/storage"
+)
+
+// fakeRates serves the rates of each series newest first, like the storage client.
+type fakeRates map[entity.Series][]entity.Rate
+
+func (f fakeRates) GetLatestRate(ctx context.Context, series entity.Series) (entity.Rate, error) {
+	rates, err := f.GetRates(ctx, series)
+	if err != nil {
+		return entity.Rate{}, err
+	}
+	return rates[0], nil
+}
+
+func (f fakeRates) GetRates(_ context.Context, series entity.Series) ([]entity.Rate, error) {
+	rates, ok := f[series]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	return rates, nil
+}
+
+func day(d int) time.Time {
+	return time.Date(2025, time.October, d, 0, 0, 0, 0, time.UTC)
+}
+
+func eurRate(code, value string, d int) entity.Rate {
+	return entity.Rate{
+		PublishedAt: day(d).Add(14 * time.Hour),
+		Date:        day(d),
+		Base:        entity.DefaultBase,
+		Code:        code,
+		Value:       value,
+		Type:        entity.RateTypeReference,
+	}
+}
+
+func TestLatest(t *testing.T) {
+	rates := fakeRates{
+		// GBP wasn't published on the 10th, so the newest common date is the 9th.
+		entity.ReferenceSeries("USD"): {eurRate("USD", "1.1568", 10), eurRate("USD", "1.1611", 9)},
+		entity.ReferenceSeries("GBP"): {eurRate("GBP", "0.87120", 9)},
+	}
+	usecase := New(rates)
+
+	tests := []struct {
+		name     string
+		series   entity.Series
+		wantDate time.Time
+		want     string
+	}{
+		{"common date", entity.Series{Base: "USD", Code: "GBP", Type: entity.RateTypeReference}, day(9), "0.750323"},
+		{"EUR against the base", entity.Series{Base: "USD", Code: "EUR", Type: entity.RateTypeReference}, day(10), "0.864454"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate, err := usecase.Latest(context.Background(), tt.series)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if rate.Value != tt.want || !rate.Date.Equal(tt.wantDate) || rate.Series() != tt.series {
+				t.Errorf("Expected %s %s on %s, got %+v", tt.series, tt.want, tt.wantDate, rate)
+			}
+		})
+	}
+
+	if _, err := usecase.Latest(context.Background(), entity.Series{Base: "JPY", Code: "USD", Type: entity.RateTypeReference}); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("Expected %v, got %v", storage.ErrNotFound, err)
+	}
+}
+
+func TestHistory(t *testing.T) {
+	// The same date in another location still has to match.
+	gbp := eurRate("GBP", "0.87000", 8)
+	gbp.Date = gbp.Date.In(time.FixedZone("CET", 3600))
+
+	rates := fakeRates{
+		entity.ReferenceSeries("USD"): {eurRate("USD", "1.1568", 10), eurRate("USD", "1.1611", 9), eurRate("USD", "1.1640", 8)},
+		entity.ReferenceSeries("GBP"): {eurRate("GBP", "0.87090", 10), gbp},
+	}
+
+	history, err := New(rates).History(context.Background(), entity.Series{Base: "GBP", Code: "USD", Type: entity.RateTypeReference})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("Expected the 2 dates with both rates, got %+v", history)
+	}
+	if history[0].Value != "1.32828" || !history[0].Date.Equal(day(10)) {
+		t.Errorf("Expected 1.32828 on the 10th first, got %+v", history[0])
+	}
+	if history[1].Value != "1.33793" || !history[1].Date.Equal(day(8)) {
+		t.Errorf("Expected 1.33793 on the 8th last, got %+v", history[1])
+	}
+}
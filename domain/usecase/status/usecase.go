@@ -0,0 +1,92 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/zemzale/backscreen-home/domain/ecb"
+	"github.com/zemzale/backscreen-home/storage"
+)
+
+type CurrencyStatus struct {
+	Code string
+	// LatestDate and LatestPublishedAt are zero when there are no rates for the currency.
+	LatestDate        time.Time
+	LatestPublishedAt time.Time
+	Stale             bool
+}
+
+type Report struct {
+	CheckedAt time.Time
+	// ExpectedDate is the newest reference date that should already be stored.
+	ExpectedDate time.Time
+	Currencies   []CurrencyStatus
+}
+
+func (r Report) Stale() bool {
+	for _, c := range r.Currencies {
+		if c.Stale {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxAge is the age of the oldest of the newest rates, so the whole set is at most this old.
+// Currencies without rates count as infinitely old.
+func (r Report) MaxAge() time.Duration {
+	var maxAge time.Duration
+	for _, c := range r.Currencies {
+		if c.LatestPublishedAt.IsZero() {
+			return time.Duration(1<<63 - 1)
+		}
+		maxAge = max(maxAge, r.CheckedAt.Sub(c.LatestPublishedAt))
+	}
+	return maxAge
+}
+
+type Usecase struct {
+	store *storage.Client
+	// grace is how long after the ECB publication the new rates are expected to be synced.
+	grace time.Duration
+}
+
+func New(store *storage.Client, grace time.Duration) *Usecase {
+	return &Usecase{
+		store: store,
+		grace: grace,
+	}
+}
+
+// Check reports how fresh the stored rates of the currencies are compared to the ECB calendar.
+func (u *Usecase) Check(ctx context.Context, currencies []string, now time.Time) (Report, error) {
+	latest, err := u.store.GetLatestPublications(ctx, currencies)
+	if err != nil {
+		return Report{}, fmt.Errorf("failed to read latest publications: %w", err)
+	}
+
+	return evaluate(currencies, latest, now, u.grace), nil
+}
+
+func evaluate(currencies []string, latest map[string]storage.LatestPublication, now time.Time, grace time.Duration) Report {
+	expected := ecb.ReferenceDate(ecb.LatestPublication(now.Add(-grace)))
+
+	report := Report{
+		CheckedAt:    now,
+		ExpectedDate: expected,
+		Currencies:   make([]CurrencyStatus, 0, len(currencies)),
+	}
+
+	for _, code := range currencies {
+		publication, ok := latest[code]
+		report.Currencies = append(report.Currencies, CurrencyStatus{
+			Code:              code,
+			LatestDate:        publication.Date,
+			LatestPublishedAt: publication.PublishedAt,
+			Stale:             !ok || publication.Date.Before(expected),
+		})
+	}
+
+	return report
+}
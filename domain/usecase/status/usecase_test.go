@@ -0,0 +1,55 @@
+package status
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zemzale/backscreen-home/domain/ecb"
+	"github.com/zemzale/backscreen-home/storage"
+)
+
+func TestEvaluate(t *testing.T) {
+	latest := map[string]storage.LatestPublication{
+		"AUD": {Date: time.Date(2025, time.October, 10, 0, 0, 0, 0, time.UTC), PublishedAt: time.Date(2025, time.October, 10, 14, 0, 0, 0, time.UTC)},
+		// A correction of the 9th published on the 10th doesn't make the 10th fresh.
+		"BGN": {Date: time.Date(2025, time.October, 9, 0, 0, 0, 0, time.UTC), PublishedAt: time.Date(2025, time.October, 10, 15, 0, 0, 0, time.UTC)},
+	}
+
+	tests := []struct {
+		name      string
+		now       time.Time
+		wantStale map[string]bool
+	}{
+		{
+			name:      "before the friday publication",
+			now:       time.Date(2025, time.October, 10, 12, 0, 0, 0, ecb.Location),
+			wantStale: map[string]bool{"AUD": false, "BGN": false, "BRL": true},
+		},
+		{
+			name:      "within the grace period after publication",
+			now:       time.Date(2025, time.October, 10, 17, 0, 0, 0, ecb.Location),
+			wantStale: map[string]bool{"AUD": false, "BGN": false, "BRL": true},
+		},
+		{
+			name:      "weekend after the friday publication",
+			now:       time.Date(2025, time.October, 12, 12, 0, 0, 0, ecb.Location),
+			wantStale: map[string]bool{"AUD": false, "BGN": true, "BRL": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := evaluate([]string{"AUD", "BGN", "BRL"}, latest, tt.now, 2*time.Hour)
+
+			for _, c := range report.Currencies {
+				if c.Stale != tt.wantStale[c.Code] {
+					t.Errorf("Expected %s stale to be %t, got %t", c.Code, tt.wantStale[c.Code], c.Stale)
+				}
+			}
+
+			if !report.Stale() {
+				t.Error("Expected report to be stale, BRL has no rates")
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package syncer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/zemzale/backscreen-home/domain/anomaly"
+	"github.com/zemzale/backscreen-home/domain/entity"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// WithFallbacks sets the sources tried one after another for a currency the primary sources
+// have no rates for, e.g. because they are down. chains overrides the default chain for single
+// currencies, an empty chain disables the fallback for the currency.
+func WithFallbacks(defaults []RateFetcher, chains map[string][]RateFetcher) Option {
+	return func(u *Usecase) {
+		u.fallbacks = defaults
+		u.chains = chains
+	}
+}
+
+// chain returns the fallback sources of the currency in the order they are tried.
+func (u *Usecase) chain(currency string) []RateFetcher {
+	if chain, ok := u.chains[currency]; ok {
+		return chain
+	}
+	return u.fallbacks
+}
+
+func (u *Usecase) hasFallbacks() bool {
+	if len(u.fallbacks) > 0 {
+		return true
+	}
+	for _, chain := range u.chains {
+		if len(chain) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// failover walks the fallback chain of the currency until a source has rates for it. reason is
+// why the primary sources were passed over. When the whole chain fails, the returned Failover
+// has no To and its Reason holds every error.
+func (u *Usecase) failover(ctx context.Context, currency string, reason error, fallbacks *fallbackFeeds) ([]anomaly.Quote, map[string]map[time.Time]string, Failover) {
+	failover := Failover{Currency: currency}
+	for _, fetcher := range u.fetchers {
+		failover.From = append(failover.From, fetcher.Name())
+	}
+
+	errs := []error{reason}
+	var (
+		quotes  []anomaly.Quote
+		reasons map[string]map[time.Time]string
+	)
+
+	for _, fetcher := range u.chain(currency) {
+		fetch := fallbacks.fetch(ctx, fetcher)
+		if fetch.result.Err != nil {
+			errs = append(errs, fetch.result.Err)
+			continue
+		}
+
+		quotes = quotesOf([]entity.Feed{fetch.feed}, currency)
+		if len(quotes) == 0 {
+			errs = append(errs, fmt.Errorf("%s has no rates for %s", fetcher.Name(), currency))
+			continue
+		}
+
+		failover.To = fetcher.Name()
+		reasons = fetch.reasons
+		break
+	}
+
+	failover.Reason = errors.Join(errs...)
+
+	logger := slog.With(slog.String("component", "sync"), slog.String("currency", currency))
+	if failover.To == "" {
+		logger.ErrorContext(ctx, "Every fallback source failed", slog.Any("error", failover.Reason))
+	} else {
+		logger.WarnContext(ctx, "Falling back to another source",
+			slog.Any("from", failover.From),
+			slog.String("to", failover.To),
+			slog.Any("reason", failover.Reason),
+		)
+	}
+	trace.SpanFromContext(ctx).AddEvent("failover", trace.WithAttributes(
+		attribute.String("currency", currency),
+		attribute.StringSlice("from", failover.From),
+		attribute.String("to", failover.To),
+	))
+
+	return quotes, reasons, failover
+}
+
+// fallbackFeeds fetches every fallback source at most once per sync, however many currencies
+// fall back to it.
+type fallbackFeeds struct {
+	usecase *Usecase
+
+	mu      sync.Mutex
+	fetches map[string]*fallbackFetch
+	// order keeps the sources in the order they were first needed, for the report.
+	order []string
+}
+
+type fallbackFetch struct {
+	once    sync.Once
+	feed    entity.Feed
+	result  SourceResult
+	reasons map[string]map[time.Time]string
+}
+
+func newFallbackFeeds(u *Usecase) *fallbackFeeds {
+	return &fallbackFeeds{usecase: u, fetches: map[string]*fallbackFetch{}}
+}
+
+func (f *fallbackFeeds) fetch(ctx context.Context, fetcher RateFetcher) *fallbackFetch {
+	f.mu.Lock()
+	fetch, ok := f.fetches[fetcher.Name()]
+	if !ok {
+		fetch = &fallbackFetch{}
+		f.fetches[fetcher.Name()] = fetch
+		f.order = append(f.order, fetcher.Name())
+	}
+	f.mu.Unlock()
+
+	fetch.once.Do(func() {
+		feeds, results := f.usecase.fetchAll(ctx, []RateFetcher{fetcher})
+		fetch.result = results[0]
+		fetch.result.Fallback = true
+		if len(feeds) > 0 {
+			fetch.feed = feeds[0]
+			fetch.reasons = f.usecase.checkItems(ctx, feeds)
+		}
+	})
+
+	return fetch
+}
+
+// results returns the fetched fallback sources, only call it once every fetch finished.
+func (f *fallbackFeeds) results() []SourceResult {
+	results := make([]SourceResult, 0, len(f.order))
+	for _, name := range f.order {
+		results = append(results, f.fetches[name].result)
+	}
+	return results
+}
+
+// feeds returns the fallback feeds that were fetched successfully.
+func (f *fallbackFeeds) feeds() []entity.Feed {
+	var feeds []entity.Feed
+	for _, name := range f.order {
+		if fetch := f.fetches[name]; fetch.result.Err == nil {
+			feeds = append(feeds, fetch.feed)
+		}
+	}
+	return feeds
+}
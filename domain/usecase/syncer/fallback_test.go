@@ -0,0 +1,63 @@
+package syncer
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zemzale/backscreen-home/domain/entity"
+)
+
+type fakeFetcher struct {
+	name  string
+	feed  entity.Feed
+	err   error
+	calls atomic.Int32
+}
+
+func (f *fakeFetcher) Name() string { return f.name }
+
+func (f *fakeFetcher) Fetch(context.Context) (entity.Feed, error) {
+	f.calls.Add(1)
+	return f.feed, f.err
+}
+
+func TestFailover(t *testing.T) {
+	date := time.Date(2025, time.October, 10, 0, 0, 0, 0, time.UTC)
+	feed := entity.Feed{Items: []entity.FeedItem{{Rates: []entity.Rate{{Code: "USD", Value: "1.1568", Date: date}}}}}
+
+	primary := &fakeFetcher{name: "lvbank", err: errors.New("bank.lv is down")}
+	broken := &fakeFetcher{name: "broken", err: errors.New("timeout")}
+	ecb := &fakeFetcher{name: "ecb", feed: feed}
+
+	u := New(nil, []RateFetcher{primary}, WithFallbacks([]RateFetcher{broken, ecb}, map[string][]RateFetcher{"GBP": {ecb}}))
+	fallbacks := newFallbackFeeds(u)
+
+	quotes, _, failover := u.failover(context.Background(), "USD", primary.err, fallbacks)
+	if failover.To != "ecb" || len(quotes) != 1 || quotes[0].Rate.Source != "ecb" {
+		t.Fatalf("Expected USD from ecb, got %+v and %v", failover, quotes)
+	}
+	if !strings.Contains(failover.Reason.Error(), "bank.lv is down") || !strings.Contains(failover.Reason.Error(), "timeout") {
+		t.Errorf("Expected both failures in the reason, got %q", failover.Reason)
+	}
+
+	_, _, failover = u.failover(context.Background(), "GBP", primary.err, fallbacks)
+	if failover.To != "" || !strings.Contains(failover.Reason.Error(), "ecb has no rates for GBP") {
+		t.Errorf("Expected the GBP chain to fail, got %+v", failover)
+	}
+
+	if ecb.calls.Load() != 1 || broken.calls.Load() != 1 {
+		t.Errorf("Expected every fallback to be fetched once, got ecb %d and broken %d", ecb.calls.Load(), broken.calls.Load())
+	}
+
+	results := fallbacks.results()
+	if len(results) != 2 || results[0].Source != "broken" || !results[1].Fallback {
+		t.Errorf("Expected both fallbacks in the report, got %+v", results)
+	}
+	if feeds := fallbacks.feeds(); len(feeds) != 1 || feeds[0].Source != "ecb" {
+		t.Errorf("Expected only the ecb feed to be imported, got %v", feeds)
+	}
+}
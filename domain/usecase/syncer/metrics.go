@@ -0,0 +1,38 @@
+package syncer
+
+import "github.com/zemzale/backscreen-home/pkg/metrics"
+
+// observe hands the outcome of the run to the metrics.
+func (r Report) observe() {
+	run := metrics.SyncRun{
+		Unchanged:     r.Unchanged,
+		PendingReview: r.PendingReview,
+	}
+
+	for _, s := range r.Sources {
+		run.Sources = append(run.Sources, metrics.SyncSource{
+			Source:        s.Source,
+			FetchDuration: s.FetchDuration,
+			Failed:        s.Err != nil,
+		})
+	}
+
+	for _, c := range r.Currencies {
+		run.Currencies = append(run.Currencies, metrics.SyncCurrency{
+			Currency:          c.Currency,
+			Inserted:          c.Inserted,
+			Duplicates:        c.Duplicates,
+			Quarantined:       c.Quarantined,
+			Disagreements:     c.Disagreements,
+			Failed:            c.Failed,
+			FetchFailed:       c.Err != nil,
+			LatestPublishedAt: c.LatestPublishedAt,
+		})
+	}
+
+	for _, f := range r.Failovers {
+		run.Failovers = append(run.Failovers, metrics.SyncFailover{Currency: f.Currency, Source: f.To})
+	}
+
+	metrics.ObserveSync(run)
+}
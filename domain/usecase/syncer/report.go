@@ -0,0 +1,127 @@
+package syncer
+
+import (
+	"log/slog"
+	"time"
+)
+
+// SourceResult describes fetching a single source.
+type SourceResult struct {
+	Source        string
+	FetchDuration time.Duration
+	// Items is how many feed items the source returned.
+	Items int
+	// Err is set when the source could not be fetched.
+	Err error
+	// BuildDate and TTL are the feed metadata, zero when the feed didn't have them.
+	BuildDate time.Time
+	TTL       time.Duration
+	// Fallback is set for sources that were only fetched because the primary ones had no rates.
+	Fallback bool
+}
+
+// Failover is a currency the primary sources had no rates for, so its fallback chain was used.
+type Failover struct {
+	Currency string
+	// From are the primary sources, To is the fallback the rates were synced from.
+	// To is empty when every fallback failed too.
+	From []string
+	To   string
+	// Reason is why the sources before To were passed over.
+	Reason error
+}
+
+// CurrencyResult describes what happened while syncing a single currency.
+type CurrencyResult struct {
+	Currency string
+	// Fetched counts the rates of the currency across all sources.
+	Fetched    int
+	Inserted   int
+	Duplicates int
+	// Quarantined counts the suspect rates that were held back for review.
+	Quarantined int
+	// Disagreements counts the source values that didn't match the agreed rate.
+	Disagreements int
+	// Failed counts the rates that could not be stored.
+	Failed int
+	// Err is set when no source could be fetched at all.
+	Err error
+	// LatestPublishedAt is the newest stored publication date after the sync.
+	LatestPublishedAt time.Time
+}
+
+type Report struct {
+	Sources    []SourceResult
+	Currencies []CurrencyResult
+	Failovers  []Failover
+	// Unchanged is set when the sync was skipped because every feed was already imported.
+	Unchanged bool
+	// PendingReview counts the quarantined rates waiting for review, including earlier syncs.
+	PendingReview int
+}
+
+// TTL is the longest ttl of the fetched sources, polling faster than that is pointless.
+func (r Report) TTL() time.Duration {
+	var ttl time.Duration
+	for _, s := range r.Sources {
+		ttl = max(ttl, s.TTL)
+	}
+	return ttl
+}
+
+func (r Report) Inserted() int {
+	return r.sum(func(c CurrencyResult) int { return c.Inserted })
+}
+
+func (r Report) Duplicates() int {
+	return r.sum(func(c CurrencyResult) int { return c.Duplicates })
+}
+
+func (r Report) Quarantined() int {
+	return r.sum(func(c CurrencyResult) int { return c.Quarantined })
+}
+
+func (r Report) Disagreements() int {
+	return r.sum(func(c CurrencyResult) int { return c.Disagreements })
+}
+
+// Failures counts sources and currencies that failed to fetch and rates that failed to store.
+func (r Report) Failures() int {
+	failures := r.sum(func(c CurrencyResult) int {
+		if c.Err != nil {
+			return c.Failed + 1
+		}
+		return c.Failed
+	})
+
+	for _, s := range r.Sources {
+		if s.Err != nil {
+			failures++
+		}
+	}
+
+	return failures
+}
+
+func (r Report) sum(f func(CurrencyResult) int) int {
+	var total int
+	for _, c := range r.Currencies {
+		total += f(c)
+	}
+	return total
+}
+
+func (r Report) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int("sources", len(r.Sources)),
+		slog.Int("currencies", len(r.Currencies)),
+		slog.Bool("unchanged", r.Unchanged),
+		slog.Int("inserted", r.Inserted()),
+		slog.Int("duplicates", r.Duplicates()),
+		slog.Int("quarantined", r.Quarantined()),
+		slog.Int("disagreements", r.Disagreements()),
+		slog.Int("failovers", len(r.Failovers)),
+		slog.Int("pending_review", r.PendingReview),
+		slog.Int("failures", r.Failures()),
+	)
+}
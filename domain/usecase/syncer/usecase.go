@@ -3,58 +3,321 @@ package syncer
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
+	"slices"
 	"sync"
+	"time"
 
+	"github.com/zemzale/backscreen-home/domain/anomaly"
 	"github.com/zemzale/backscreen-home/domain/entity"
+	"github.com/zemzale/backscreen-home/pkg/telemetry"
 	"github.com/zemzale/backscreen-home/storage"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var tracer = telemetry.Tracer("github.com/zemzale/backscreen-home/domain/usecase/syncer")
+
 type RateFetcher interface {
-	Fetch(ctx context.Context, currency string) ([]entity.Rate, error)
+	// Name identifies the source in logs, metrics and reports.
+	Name() string
+	// Fetch downloads everything the source currently publishes.
+	Fetch(ctx context.Context) (entity.Feed, error)
 }
 
 type Usecase struct {
-	store   *storage.Client
-	fetcher RateFetcher
+	store *storage.Client
+	// fetchers are listed by preference, the first source wins ties between disagreeing values.
+	fetchers []RateFetcher
+	// force syncs the feeds even when they didn't change since the last import.
+	force bool
+	// checker is nil when the rates are stored without sanity checks.
+	checker *anomaly.Checker
+	// tolerance is the largest relative difference between sources that still agree.
+	tolerance float64
+	// fallbacks is the default fallback chain, chains overrides it for single currencies.
+	fallbacks []RateFetcher
+	chains    map[string][]RateFetcher
+}
+
+type Option func(*Usecase)
+
+// WithForce syncs the feeds even when their build dates show they were already imported.
+func WithForce() Option {
+	return func(u *Usecase) {
+		u.force = true
+	}
+}
+
+// WithTolerance sets the largest relative difference between sources that still counts as
+// agreeing, e.g. 0.0001 for 0.01%. Without it the values have to be equal.
+func WithTolerance(tolerance float64) Option {
+	return func(u *Usecase) {
+		u.tolerance = tolerance
+	}
 }
 
-func New(store *storage.Client, fetcher RateFetcher) *Usecase {
-	return &Usecase{
-		store:   store,
-		fetcher: fetcher,
+func New(store *storage.Client, fetchers []RateFetcher, opts ...Option) *Usecase {
+	u := &Usecase{
+		store:    store,
+		fetchers: fetchers,
+	}
+	for _, opt := range opts {
+		opt(u)
 	}
+	return u
 }
 
-func (u *Usecase) Sync(ctx context.Context, currencies []string) {
+func (u *Usecase) Sync(ctx context.Context, currencies []string) Report {
+	ctx, span := tracer.Start(ctx, "syncer.Sync", trace.WithAttributes(attribute.StringSlice("currencies", currencies)))
+	defer span.End()
+
+	report := u.sync(ctx, currencies)
+
+	// Rates quarantined by earlier syncs are counted too, until someone reviews them.
+	pending, err := u.store.CountQuarantinedRates(ctx, entity.QuarantinePending)
+	if err != nil {
+		logger := slog.With(slog.String("component", "sync"))
+		logger.ErrorContext(ctx, "Failed to count quarantined rates", slog.Any("error", err))
+	} else {
+		report.PendingReview = pending
+	}
+
+	report.observe()
+
+	return report
+}
+
+func (u *Usecase) sync(ctx context.Context, currencies []string) Report {
+	span := trace.SpanFromContext(ctx)
+
 	logger := slog.With(slog.String("component", "sync"))
 
+	var report Report
+
+	feeds, sources := u.fetchAll(ctx, u.fetchers)
+	report.Sources = sources
+
+	// primaryErr is why a currency without rates from the primary sources falls back.
+	var primaryErr error
+	if len(feeds) == 0 {
+		errs := make([]error, 0, len(sources))
+		for _, source := range sources {
+			errs = append(errs, source.Err)
+		}
+		primaryErr = errors.Join(errs...)
+
+		span.RecordError(primaryErr)
+		span.SetStatus(codes.Error, "failed to fetch rates")
+
+		if !u.hasFallbacks() {
+			for _, currency := range currencies {
+				report.Currencies = append(report.Currencies, CurrencyResult{Currency: currency, Err: primaryErr})
+			}
+			return report
+		}
+	}
+
+	if len(feeds) > 0 && !u.force && u.unchanged(ctx, feeds) {
+		logger.InfoContext(ctx, "Feeds unchanged since the last sync, skipping")
+		span.SetAttributes(attribute.Bool("unchanged", true))
+		report.Unchanged = true
+
+		for _, currency := range currencies {
+			report.Currencies = append(report.Currencies, CurrencyResult{
+				Currency:          currency,
+				LatestPublishedAt: u.latestPublishedAt(ctx, currency, entity.ReferenceSeries(currency)),
+			})
+		}
+		return report
+	}
+
+	itemReasons := u.checkItems(ctx, feeds)
+
+	fallbacks := newFallbackFeeds(u)
+
+	// Every goroutine writes only its own element, so no locking is needed.
+	results := make([]CurrencyResult, len(currencies))
+	failovers := make([]*Failover, len(currencies))
+
 	// This could be reworked to use channels and remove the WaitGroup, but for such a small slice of elemetnts,
 	// The performance actually goes down, since it does require more allocations up front
 	// If there were more elements to sync, it would be better to rework it.
 	wg := sync.WaitGroup{}
 	wg.Add(len(currencies))
 
-	for _, currency := range currencies {
+	for i, currency := range currencies {
 		go func(wg *sync.WaitGroup, currency string) {
 			defer wg.Done()
 
 			logger.InfoContext(ctx, "Syncing currency", slog.String("currency", currency))
 
-			u.syncCurrency(ctx, currency)
+			quotes, reasons := quotesOf(feeds, currency), itemReasons
+			if len(quotes) == 0 && len(u.chain(currency)) > 0 {
+				reason := primaryErr
+				if reason == nil {
+					reason = fmt.Errorf("no primary source has rates for %s", currency)
+				}
+
+				var failover Failover
+				quotes, reasons, failover = u.failover(ctx, currency, reason, fallbacks)
+				failovers[i] = &failover
+
+				if failover.To == "" {
+					results[i] = CurrencyResult{Currency: currency, Err: failover.Reason}
+					return
+				}
+			} else if len(feeds) == 0 {
+				results[i] = CurrencyResult{Currency: currency, Err: primaryErr}
+				return
+			}
+
+			results[i] = u.syncCurrency(ctx, currency, quotes, reasons)
 		}(&wg, currency)
 	}
 
 	wg.Wait()
+
+	report.Currencies = results
+	report.Sources = append(report.Sources, fallbacks.results()...)
+	for _, failover := range failovers {
+		if failover != nil {
+			report.Failovers = append(report.Failovers, *failover)
+		}
+	}
+
+	// The imports mark the feeds as synced, so feeds are synced again after any failure.
+	if report.Failures() > 0 {
+		return report
+	}
+	for _, feed := range append(feeds, fallbacks.feeds()...) {
+		if err := u.store.StoreImport(ctx, feed); err != nil {
+			logger.ErrorContext(ctx, "Failed to store raw import", slog.String("source", feed.Source), slog.Any("error", err))
+		}
+	}
+
+	return report
 }
 
-func (u *Usecase) syncCurrency(ctx context.Context, currency string) {
+// fetchAll downloads every source at the same time. The feeds keep the order of the fetchers,
+// sources that failed are left out.
+func (u *Usecase) fetchAll(ctx context.Context, fetchers []RateFetcher) ([]entity.Feed, []SourceResult) {
+	logger := slog.With(slog.String("component", "sync"))
+
+	feeds := make([]entity.Feed, len(fetchers))
+	results := make([]SourceResult, len(fetchers))
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(fetchers))
+
+	for i, fetcher := range fetchers {
+		go func() {
+			defer wg.Done()
+
+			start := time.Now()
+			feed, err := fetcher.Fetch(ctx)
+			results[i] = SourceResult{Source: fetcher.Name(), FetchDuration: time.Since(start), Err: err}
+			if err != nil {
+				logger.ErrorContext(ctx, "Failed to fetch rates",
+					slog.String("source", fetcher.Name()),
+					slog.Any("error", err),
+				)
+				return
+			}
+
+			feed.Source = fetcher.Name()
+			feeds[i] = feed
+			results[i].Items = len(feed.Items)
+			results[i].BuildDate = feed.BuildDate
+			results[i].TTL = feed.TTL
+		}()
+	}
+
+	wg.Wait()
+
+	fetched := make([]entity.Feed, 0, len(feeds))
+	for i, feed := range feeds {
+		if results[i].Err == nil {
+			fetched = append(fetched, feed)
+		}
+	}
+
+	return fetched, results
+}
+
+// quotesOf returns the rates every feed has for the currency, in the order of the feeds.
+func quotesOf(feeds []entity.Feed, currency string) []anomaly.Quote {
+	var quotes []anomaly.Quote
+	for _, feed := range feeds {
+		for _, rate := range feed.RatesOf(currency) {
+			rate.Source = feed.Source
+			quotes = append(quotes, anomaly.Quote{Source: feed.Source, Rate: rate})
+		}
+	}
+	return quotes
+}
+
+// unchanged reports whether feeds with the same build dates were all imported already.
+// Feeds without a build date are always synced.
+func (u *Usecase) unchanged(ctx context.Context, feeds []entity.Feed) bool {
+	logger := slog.With(slog.String("component", "sync"))
+
+	for _, feed := range feeds {
+		if feed.BuildDate.IsZero() {
+			return false
+		}
+
+		lastBuild, err := u.store.GetLatestFeedBuild(ctx, feed.Source)
+		if err != nil {
+			logger.ErrorContext(ctx, "Failed to read the last feed build date",
+				slog.String("source", feed.Source),
+				slog.Any("error", err),
+			)
+			return false
+		}
+
+		if lastBuild.IsZero() || feed.BuildDate.After(lastBuild) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (u *Usecase) syncCurrency(ctx context.Context, currency string, quotes []anomaly.Quote, itemReasons map[string]map[time.Time]string) CurrencyResult {
+	ctx, span := tracer.Start(ctx, "syncer.syncCurrency", trace.WithAttributes(attribute.String("currency", currency)))
+	defer span.End()
+
 	logger := slog.With(slog.String("component", "sync"), slog.String("currency", currency))
 
-	rates, err := u.fetcher.Fetch(ctx, currency)
-	if err != nil {
-		logger.ErrorContext(ctx, "Failed to fetch rate", slog.Any("currency", currency), slog.Any("error", err))
-		return
+	result := CurrencyResult{Currency: currency}
+	result.Fetched = len(quotes)
+
+	quotes, result.Duplicates = u.skipStored(ctx, currency, quotes)
+
+	rates, suspects, sources := u.validate(ctx, currency, quotes, itemReasons)
+	for _, source := range sources {
+		if !source.Agrees {
+			result.Disagreements++
+		}
+	}
+
+	if err := u.store.StoreSourceRates(ctx, sources); err != nil {
+		// Provenance is only informational, the rates are stored anyway.
+		logger.ErrorContext(ctx, "Failed to store source rates", slog.Any("error", err))
+	}
+
+	if len(suspects) > 0 {
+		quarantined, err := u.store.QuarantineRates(ctx, suspects)
+		if err != nil {
+			logger.ErrorContext(ctx, "Failed to quarantine rates", slog.Any("error", err))
+			result.Failed += len(suspects)
+		} else {
+			// The rest were quarantined by an earlier sync.
+			result.Quarantined = quarantined
+		}
 	}
 
 	// This is actually the fasttest way since it doeesn't require allocations (for such small slices)
@@ -68,9 +331,53 @@ func (u *Usecase) syncCurrency(ctx context.Context, currency string) {
 					"Rate already exists in database",
 					slog.Any("rate", rate),
 				)
+				result.Duplicates++
 				continue
 			}
 			logger.ErrorContext(ctx, "Failed to store rate", slog.Any("rate", rate), slog.Any("error", err))
+			result.Failed++
+			continue
+		}
+		result.Inserted++
+	}
+
+	result.LatestPublishedAt = u.latestPublishedAt(ctx, currency, seriesOf(currency, quotes)...)
+
+	return result
+}
+
+// seriesOf returns the series the quotes belong to, the ECB reference series of the currency
+// when there are no quotes.
+func seriesOf(currency string, quotes []anomaly.Quote) []entity.Series {
+	var series []entity.Series
+	for _, quote := range quotes {
+		if !slices.Contains(series, quote.Rate.Series()) {
+			series = append(series, quote.Rate.Series())
+		}
+	}
+	if len(series) == 0 {
+		series = append(series, entity.ReferenceSeries(currency))
+	}
+	return series
+}
+
+// latestPublishedAt returns the newest stored publication of the series of the currency, zero
+// when there is none.
+func (u *Usecase) latestPublishedAt(ctx context.Context, currency string, series ...entity.Series) time.Time {
+	logger := slog.With(slog.String("component", "sync"), slog.String("currency", currency))
+
+	var latest time.Time
+	for _, s := range series {
+		rate, err := u.store.GetLatestRate(ctx, s)
+		if err != nil {
+			if !errors.Is(err, storage.ErrNotFound) {
+				logger.ErrorContext(ctx, "Failed to read latest rate", slog.Any("error", err))
+			}
+			continue
+		}
+		if rate.PublishedAt.After(latest) {
+			latest = rate.PublishedAt
 		}
 	}
+	return latest
 }
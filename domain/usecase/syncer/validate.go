@@ -0,0 +1,247 @@
+package syncer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/zemzale/backscreen-home/domain/anomaly"
+	"github.com/zemzale/backscreen-home/domain/entity"
+	"github.com/zemzale/backscreen-home/storage"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// WithChecker runs the sanity checks on the fetched rates before they are stored.
+// Suspect rates are quarantined for review instead.
+func WithChecker(checker *anomaly.Checker) Option {
+	return func(u *Usecase) {
+		u.checker = checker
+	}
+}
+
+// checkItems returns why the items of the feeds can't be trusted by source and publication
+// time. A publish that misses expected currencies may be broken in other ways too, so all of
+// its rates are suspect.
+func (u *Usecase) checkItems(ctx context.Context, feeds []entity.Feed) map[string]map[time.Time]string {
+	reasons := map[string]map[time.Time]string{}
+	if u.checker == nil {
+		return reasons
+	}
+
+	logger := slog.With(slog.String("component", "sync"))
+
+	for _, feed := range feeds {
+		for _, item := range feed.Items {
+			missing := u.checker.Missing(item)
+			if len(missing) == 0 {
+				continue
+			}
+
+			logger.WarnContext(ctx, "Feed item is missing currencies",
+				slog.String("source", feed.Source),
+				slog.String("guid", item.GUID),
+				slog.Any("missing", missing),
+			)
+			if reasons[feed.Source] == nil {
+				reasons[feed.Source] = map[time.Time]string{}
+			}
+			reasons[feed.Source][item.PublishedAt] = fmt.Sprintf("%s feed item %s is missing %s", feed.Source, item.GUID, strings.Join(missing, ", "))
+		}
+	}
+
+	return reasons
+}
+
+// skipStored drops the quotes of the reference dates that are already stored, so they aren't
+// checked again on every sync. It returns how many dates were skipped.
+func (u *Usecase) skipStored(ctx context.Context, currency string, quotes []anomaly.Quote) ([]anomaly.Quote, int) {
+	logger := slog.With(slog.String("component", "sync"), slog.String("currency", currency))
+
+	oldest := map[entity.Series]time.Time{}
+	for _, quote := range quotes {
+		series := quote.Rate.Series()
+		if since, ok := oldest[series]; !ok || quote.Rate.Date.Before(since) {
+			oldest[series] = quote.Rate.Date
+		}
+	}
+
+	type key struct {
+		series entity.Series
+		date   time.Time
+	}
+
+	stored := map[key]bool{}
+	for series, since := range oldest {
+		dates, err := u.store.GetRateDates(ctx, series, since)
+		if err != nil {
+			// The stored rates are checked again and then skipped as duplicates.
+			logger.ErrorContext(ctx, "Failed to read stored rate dates",
+				slog.String("series", series.String()),
+				slog.Any("error", err),
+			)
+			continue
+		}
+		for _, date := range dates {
+			stored[key{series: series, date: date}] = true
+		}
+	}
+
+	fresh := make([]anomaly.Quote, 0, len(quotes))
+	skipped := map[key]bool{}
+	for _, quote := range quotes {
+		k := key{series: quote.Rate.Series(), date: quote.Rate.Date}
+		if stored[k] {
+			skipped[k] = true
+			continue
+		}
+		fresh = append(fresh, quote)
+	}
+
+	return fresh, len(skipped)
+}
+
+// validate splits the quotes of a currency into the rates to store and the suspect ones, one
+// per reference date. The sources have to agree on the rate of a date first, then the agreed
+// rate is compared to the previous accepted one, starting with the newest stored rate before
+// the oldest fetched date. sources records what every source said about each date.
+func (u *Usecase) validate(ctx context.Context, currency string, quotes []anomaly.Quote, itemReasons map[string]map[time.Time]string) (accepted []entity.Rate, suspects []entity.QuarantinedRate, sources []entity.SourceRate) {
+	if len(quotes) == 0 {
+		return nil, nil, nil
+	}
+
+	logger := slog.With(slog.String("component", "sync"), slog.String("currency", currency))
+
+	dates := quotesByDate(quotes)
+
+	// previous holds the last accepted rate of every series, read from the store when the
+	// series first shows up, which is at its oldest fetched date.
+	previous := map[entity.Series]entity.Rate{}
+	previousOf := func(rate entity.Rate) entity.Rate {
+		series := rate.Series()
+		if prev, ok := previous[series]; ok {
+			return prev
+		}
+
+		prev, err := u.store.GetPreviousRate(ctx, series, rate.Date)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			// Without a previous rate the first one is only checked on its own.
+			logger.ErrorContext(ctx, "Failed to read previous rate", slog.String("series", series.String()), slog.Any("error", err))
+		}
+		previous[series] = prev
+		return prev
+	}
+
+	for _, quotes := range dates {
+		var trusted, untrusted []anomaly.Quote
+		var untrustedReasons []string
+		for _, quote := range quotes {
+			if reason := itemReasons[quote.Source][quote.Rate.PublishedAt]; reason != "" {
+				untrusted = append(untrusted, quote)
+				untrustedReasons = append(untrustedReasons, reason)
+				continue
+			}
+			trusted = append(trusted, quote)
+		}
+
+		for _, quote := range untrusted {
+			sources = append(sources, entity.SourceRate{Source: quote.Source, Rate: quote.Rate})
+		}
+
+		if len(trusted) == 0 {
+			suspects = append(suspects, u.quarantine(ctx, logger, untrusted[0], strings.Join(untrustedReasons, "; ")))
+			continue
+		}
+
+		agreed, agrees, ok := anomaly.Reconcile(trusted, u.tolerance)
+		for i, quote := range trusted {
+			sources = append(sources, entity.SourceRate{Source: quote.Source, Rate: quote.Rate, Agrees: agrees[i]})
+			if ok && !agrees[i] {
+				logger.WarnContext(ctx, "Source disagrees with the agreed rate",
+					slog.String("source", quote.Source),
+					slog.String("value", quote.Rate.Value),
+					slog.String("agreed_source", agreed.Source),
+					slog.String("agreed_value", agreed.Rate.Value),
+				)
+			}
+		}
+
+		if !ok {
+			values := make([]string, 0, len(trusted))
+			for _, quote := range trusted {
+				values = append(values, quote.Source+" "+quote.Rate.Value)
+			}
+			suspects = append(suspects, u.quarantine(ctx, logger, agreed, "sources disagree: "+strings.Join(values, ", ")))
+			continue
+		}
+
+		if u.checker != nil {
+			if reason := u.checker.Check(agreed.Rate, previousOf(agreed.Rate)); reason != "" {
+				suspects = append(suspects, u.quarantine(ctx, logger, agreed, reason))
+				continue
+			}
+		}
+
+		accepted = append(accepted, agreed.Rate)
+		previous[agreed.Rate.Series()] = agreed.Rate
+	}
+
+	return accepted, suspects, sources
+}
+
+func (u *Usecase) quarantine(ctx context.Context, logger *slog.Logger, quote anomaly.Quote, reason string) entity.QuarantinedRate {
+	logger.WarnContext(ctx, "Quarantining suspect rate", slog.Any("rate", quote.Rate), slog.String("reason", reason))
+	trace.SpanFromContext(ctx).AddEvent("quarantined rate", trace.WithAttributes(
+		attribute.String("source", quote.Source),
+		attribute.String("value", quote.Rate.Value),
+		attribute.String("reason", reason),
+	))
+
+	return entity.QuarantinedRate{
+		Rate:   quote.Rate,
+		Source: quote.Source,
+		Reason: reason,
+	}
+}
+
+// quotesByDate groups the quotes by series and reference date, oldest first. The quotes of a
+// date keep the order of the sources, and a source that published a date more than once only
+// keeps its newest publication.
+func quotesByDate(quotes []anomaly.Quote) [][]anomaly.Quote {
+	type key struct {
+		series entity.Series
+		date   time.Time
+	}
+
+	var dates [][]anomaly.Quote
+	index := map[key]int{}
+
+	for _, quote := range quotes {
+		k := key{series: quote.Rate.Series(), date: quote.Rate.Date}
+		i, ok := index[k]
+		if !ok {
+			index[k] = len(dates)
+			dates = append(dates, []anomaly.Quote{quote})
+			continue
+		}
+
+		j := slices.IndexFunc(dates[i], func(q anomaly.Quote) bool { return q.Source == quote.Source })
+		if j < 0 {
+			dates[i] = append(dates[i], quote)
+			continue
+		}
+		if quote.Rate.PublishedAt.After(dates[i][j].Rate.PublishedAt) {
+			dates[i][j] = quote
+		}
+	}
+
+	slices.SortStableFunc(dates, func(a, b []anomaly.Quote) int {
+		return a[0].Rate.Date.Compare(b[0].Rate.Date)
+	})
+
+	return dates
+}
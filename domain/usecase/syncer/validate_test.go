@@ -0,0 +1,154 @@
+package syncer
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/zemzale/backscreen-home/domain/anomaly"
+	"github.com/zemzale/backscreen-home/domain/entity"
+	"github.com/zemzale/backscreen-home/storage"
+)
+
+func quote(source, value string, day int) anomaly.Quote {
+	date := time.Date(2025, time.October, day, 0, 0, 0, 0, time.UTC)
+	return anomaly.Quote{Source: source, Rate: entity.Rate{Base: entity.DefaultBase, Code: "USD", Value: value, Type: entity.RateTypeReference, Date: date, PublishedAt: date.Add(14 * time.Hour)}}
+}
+
+func newMockStore(t *testing.T) (*storage.Client, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return storage.New(sqlx.NewDb(db, "mysql")), mock
+}
+
+func day(day int) time.Time {
+	return time.Date(2025, time.October, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestSkipStored(t *testing.T) {
+	store, mock := newMockStore(t)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT DISTINCT rate_date FROM rates")).
+		WithArgs(entity.DefaultBase, "USD", string(entity.RateTypeReference), "2025-10-09").
+		WillReturnRows(sqlmock.NewRows([]string{"rate_date"}).AddRow(day(9)))
+
+	u := New(store, nil)
+
+	quotes, skipped := u.skipStored(context.Background(), "USD", []anomaly.Quote{
+		quote("lvbank", "1.1600", 9),
+		quote("ecb", "1.1600", 9),
+		quote("lvbank", "1.1568", 10),
+	})
+
+	if skipped != 1 {
+		t.Errorf("Expected 1 skipped date, got %d", skipped)
+	}
+	if len(quotes) != 1 || quotes[0].Rate.Date.Day() != 10 {
+		t.Errorf("Expected only the quote of the 10th to be left, got %v", quotes)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestValidateChecksPreviousRate(t *testing.T) {
+	store, mock := newMockStore(t)
+	mock.ExpectQuery(regexp.QuoteMeta("AND rate_date < ?")).
+		WithArgs(entity.DefaultBase, "USD", string(entity.RateTypeReference), "2025-10-09").
+		WillReturnRows(sqlmock.NewRows([]string{"code", "value", "rate_date", "published_at", "base", "rate_type", "source"}).
+			AddRow("USD", "1.1000", day(8), day(8).Add(14*time.Hour), entity.DefaultBase, string(entity.RateTypeReference), "lvbank"))
+
+	u := New(store, nil, WithChecker(anomaly.New(anomaly.Config{MaxChange: 0.05})))
+
+	accepted, suspects, _ := u.validate(context.Background(), "USD", []anomaly.Quote{quote("lvbank", "1.3000", 9), quote("lvbank", "1.1100", 10)}, nil)
+
+	if len(suspects) != 1 || suspects[0].Rate.Date.Day() != 9 || !strings.Contains(suspects[0].Reason, "since 2025-10-08") {
+		t.Errorf("Expected the jump on the 9th to be quarantined, got %v", suspects)
+	}
+	// The suspect rate isn't accepted, so the 10th is compared to the stored rate again.
+	if len(accepted) != 1 || accepted[0].Date.Day() != 10 {
+		t.Errorf("Expected the 10th to be accepted, got %v", accepted)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestValidateReconciles(t *testing.T) {
+	u := New(nil, nil, WithTolerance(0.0001))
+
+	quotes := []anomaly.Quote{
+		quote("lvbank", "1.1568", 10),
+		quote("lvbank", "1.1600", 9),
+		quote("ecb", "1.1568", 10),
+		quote("fed", "1.1570", 10),
+		quote("ecb", "1.1700", 9),
+	}
+
+	accepted, suspects, sources := u.validate(context.Background(), "USD", quotes, nil)
+
+	if len(accepted) != 1 || accepted[0].Value != "1.1568" || accepted[0].Date.Day() != 10 {
+		t.Errorf("Expected 1.1568 on the 10th to be accepted, got %v", accepted)
+	}
+
+	if len(suspects) != 1 {
+		t.Fatalf("Expected 1 suspect, got %d", len(suspects))
+	}
+	if want := "sources disagree: lvbank 1.1600, ecb 1.1700"; suspects[0].Reason != want {
+		t.Errorf("Expected reason %q, got %q", want, suspects[0].Reason)
+	}
+	if suspects[0].Source != "lvbank" {
+		t.Errorf("Expected the preferred source to be quarantined, got %s", suspects[0].Source)
+	}
+
+	if len(sources) != 5 {
+		t.Fatalf("Expected 5 source rates, got %d", len(sources))
+	}
+	for _, source := range sources[2:] {
+		if want := source.Source != "fed"; source.Agrees != want {
+			t.Errorf("Expected %s agrees to be %t, got %t", source.Source, want, source.Agrees)
+		}
+	}
+}
+
+func TestValidateSkipsIncompleteItems(t *testing.T) {
+	u := New(nil, nil)
+
+	quotes := []anomaly.Quote{quote("lvbank", "1.1568", 10), quote("ecb", "1.1700", 10)}
+	reasons := map[string]map[time.Time]string{
+		"ecb": {quotes[1].Rate.PublishedAt: "ecb feed item 2025-10-10 is missing GBP"},
+	}
+
+	accepted, suspects, _ := u.validate(context.Background(), "USD", quotes, reasons)
+	if len(accepted) != 1 || accepted[0].Value != "1.1568" || len(suspects) != 0 {
+		t.Errorf("Expected only the complete source to count, got %v and %v", accepted, suspects)
+	}
+
+	accepted, suspects, _ = u.validate(context.Background(), "USD", quotes[1:], reasons)
+	if len(accepted) != 0 || len(suspects) != 1 || !strings.Contains(suspects[0].Reason, "missing GBP") {
+		t.Errorf("Expected the incomplete rate to be quarantined, got %v and %v", accepted, suspects)
+	}
+}
+
+func TestQuotesByDate(t *testing.T) {
+	later := quote("lvbank", "1.1570", 10)
+	later.Rate.PublishedAt = later.Rate.PublishedAt.Add(time.Hour)
+
+	dates := quotesByDate([]anomaly.Quote{quote("lvbank", "1.1568", 10), quote("ecb", "1.1568", 10), later, quote("ecb", "1.16", 9)})
+
+	if len(dates) != 2 || dates[0][0].Rate.Date.Day() != 9 {
+		t.Fatalf("Expected 2 dates starting with the 9th, got %v", dates)
+	}
+	if len(dates[1]) != 2 || dates[1][0].Rate.Value != "1.1570" || dates[1][1].Source != "ecb" {
+		t.Errorf("Expected the newest lvbank quote before ecb, got %v", dates[1])
+	}
+}
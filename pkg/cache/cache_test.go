@@ -0,0 +1,62 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	now := time.Date(2025, time.October, 10, 0, 0, 0, 0, time.UTC)
+	c := New[string, int](2, time.Minute)
+	c.now = func() time.Time { return now }
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Fatalf("Expected a=1, got %d (%t)", v, ok)
+	}
+
+	// b is the least recently used entry now, so it is evicted.
+	c.Set("c", 3)
+	if _, ok := c.Get("b"); ok {
+		t.Error("Expected b to be evicted")
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, ok := c.Get("a"); ok {
+		t.Error("Expected a to be expired")
+	}
+
+	c.Set("d", 4)
+	c.Purge()
+	if _, ok := c.Get("d"); ok {
+		t.Error("Expected d to be purged")
+	}
+
+	stats := c.Stats()
+	if stats.Hits != 1 || stats.Misses != 3 || stats.Evictions != 1 || stats.Entries != 0 {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+}
+
+func TestSetIfGeneration(t *testing.T) {
+	c := New[string, int](2, time.Minute)
+
+	// A load that started before a purge must not store its value.
+	generation := c.Generation()
+	c.Purge()
+	if c.SetIfGeneration("a", 1, generation) {
+		t.Error("Expected the value loaded before the purge to be dropped")
+	}
+	if _, ok := c.Get("a"); ok {
+		t.Error("Expected a not to be cached")
+	}
+
+	if !c.SetIfGeneration("a", 2, c.Generation()) {
+		t.Error("Expected the value to be stored")
+	}
+	if v, ok := c.Get("a"); !ok || v != 2 {
+		t.Errorf("Expected a=2, got %d (%t)", v, ok)
+	}
+}
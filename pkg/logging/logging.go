@@ -0,0 +1,206 @@
+// Package logging configures the default slog logger.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"os"
+	"strings"
+	"sync"
+)
+
+// ComponentKey is the attribute the loggers in this service use to tell components apart.
+const ComponentKey = "component"
+
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+type Config struct {
+	Level slog.Level
+	// Format is either text or json.
+	Format string
+	// Output is stdout, stderr or a file path.
+	Output string
+	// Components overrides the level for loggers with a matching component attribute.
+	Components map[string]slog.Level
+}
+
+// Levels holds the global and per-component levels. They can be changed while the process runs.
+type Levels struct {
+	mu         sync.RWMutex
+	level      slog.Level
+	components map[string]slog.Level
+}
+
+func NewLevels(level slog.Level, components map[string]slog.Level) *Levels {
+	l := &Levels{level: level, components: map[string]slog.Level{}}
+	maps.Copy(l.components, components)
+	return l
+}
+
+// For returns the level in effect for the component.
+func (l *Levels) For(component string) slog.Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if level, ok := l.components[component]; ok {
+		return level
+	}
+	return l.level
+}
+
+func (l *Levels) Level() slog.Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return l.level
+}
+
+func (l *Levels) SetLevel(level slog.Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.level = level
+}
+
+func (l *Levels) SetComponentLevel(component string, level slog.Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.components[component] = level
+}
+
+// ResetComponentLevel makes the component follow the global level again.
+func (l *Levels) ResetComponentLevel(component string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.components, component)
+}
+
+func (l *Levels) Components() map[string]slog.Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return maps.Clone(l.components)
+}
+
+// Setup installs the default slog logger. The returned closer releases the log file, if any.
+func Setup(cfg Config) (*Levels, io.Closer, error) {
+	var (
+		w      io.Writer
+		closer io.Closer = io.NopCloser(nil)
+	)
+
+	switch cfg.Output {
+	case "", "stderr":
+		w = os.Stderr
+	case "stdout":
+		w = os.Stdout
+	default:
+		file, err := os.OpenFile(cfg.Output, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open log file: %w", err)
+		}
+		w, closer = file, file
+	}
+
+	// Filtering is done by the component handler, the inner handler lets everything through.
+	opts := &slog.HandlerOptions{Level: slog.Level(-128)}
+
+	var inner slog.Handler
+	switch cfg.Format {
+	case "", FormatText:
+		inner = slog.NewTextHandler(w, opts)
+	case FormatJSON:
+		inner = slog.NewJSONHandler(w, opts)
+	default:
+		return nil, nil, fmt.Errorf("unknown log format %q", cfg.Format)
+	}
+
+	levels := NewLevels(cfg.Level, cfg.Components)
+	slog.SetDefault(slog.New(&componentHandler{inner: inner, levels: levels}))
+
+	return levels, closer, nil
+}
+
+// ParseLevel parses a level name like debug or warn, case insensitive.
+func ParseLevel(s string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.TrimSpace(s))); err != nil {
+		return 0, fmt.Errorf("invalid log level %q", s)
+	}
+	return level, nil
+}
+
+// ParseComponentLevels parses comma separated "<component>=<level>" pairs, e.g. "sync=debug,api=warn".
+func ParseComponentLevels(s string) (map[string]slog.Level, error) {
+	levels := map[string]slog.Level{}
+
+	for pair := range strings.SplitSeq(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		component, levelStr, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid component level %q, expected <component>=<level>", pair)
+		}
+
+		level, err := ParseLevel(levelStr)
+		if err != nil {
+			return nil, err
+		}
+
+		levels[strings.TrimSpace(component)] = level
+	}
+
+	return levels, nil
+}
+
+// componentHandler filters records by the level of the component the logger was created for
+// with slog.With("component", ...).
+type componentHandler struct {
+	inner     slog.Handler
+	levels    *Levels
+	component string
+	grouped   bool
+}
+
+func (h *componentHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.levels.For(h.component)
+}
+
+func (h *componentHandler) Handle(ctx context.Context, r slog.Record) error {
+	return h.inner.Handle(ctx, r)
+}
+
+func (h *componentHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.inner = h.inner.WithAttrs(attrs)
+
+	// Attributes inside a group are not the component of the logger.
+	if !h.grouped {
+		for _, attr := range attrs {
+			if attr.Key == ComponentKey {
+				clone.component = attr.Value.String()
+			}
+		}
+	}
+
+	return &clone
+}
+
+func (h *componentHandler) WithGroup(name string) slog.Handler {
+	clone := *h
+	clone.inner = h.inner.WithGroup(name)
+	clone.grouped = true
+
+	return &clone
+}
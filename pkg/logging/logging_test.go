@@ -0,0 +1,46 @@
+package logging
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestComponentHandler(t *testing.T) {
+	var buf bytes.Buffer
+	levels := NewLevels(slog.LevelInfo, map[string]slog.Level{"sync": slog.LevelDebug})
+	logger := slog.New(&componentHandler{inner: slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.Level(-128)}), levels: levels})
+
+	logger.With(ComponentKey, "api").Debug("api debug")
+	logger.With(ComponentKey, "sync").Debug("sync debug")
+	logger.Info("root info")
+
+	levels.SetComponentLevel("api", slog.LevelDebug)
+	logger.With(ComponentKey, "api").Debug("api debug after change")
+
+	out := buf.String()
+	for _, want := range []string{"sync debug", "root info", "api debug after change"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q to be logged, got %s", want, out)
+		}
+	}
+	if strings.Contains(out, "msg=\"api debug\"") {
+		t.Errorf("Expected api debug to be filtered, got %s", out)
+	}
+}
+
+func TestParseComponentLevels(t *testing.T) {
+	levels, err := ParseComponentLevels("sync=debug, api=WARN")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if levels["sync"] != slog.LevelDebug || levels["api"] != slog.LevelWarn {
+		t.Errorf("Unexpected levels %v", levels)
+	}
+
+	if _, err := ParseComponentLevels("sync=loud"); err == nil {
+		t.Error("Expected error for unknown level")
+	}
+}
@@ -0,0 +1,323 @@
+// Package metrics holds the Prometheus metrics of the API and the syncer.
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/client_golang/prometheus/push"
+	"github.com/zemzale/backscreen-home/pkg/cache"
+)
+
+const namespace = "backscreen"
+
+// Registry is used instead of the global default registry, so only metrics registered here are exposed.
+var Registry = prometheus.NewRegistry()
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "HTTP requests by route, method and status code.",
+	}, []string{"route", "method", "status"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request latency by route, method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
+
+	// The sync metrics describe the last run, the sync command is short-lived and pushes them or
+	// writes them to a textfile after every run, so counters would start over in every process.
+	syncFetchDuration = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "sync",
+		Name:      "fetch_duration_seconds",
+		Help:      "Time spent fetching rates from a source in the last sync.",
+	}, []string{"source"})
+
+	syncInserted = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "sync",
+		Name:      "rates_inserted",
+		Help:      "Rates stored by the last sync.",
+	}, []string{"currency"})
+
+	syncDuplicates = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "sync",
+		Name:      "rates_duplicate",
+		Help:      "Fetched rates that were already stored in the last sync.",
+	}, []string{"currency"})
+
+	syncQuarantined = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "sync",
+		Name:      "rates_quarantined",
+		Help:      "Fetched rates held back for review by the last sync because they failed the sanity checks.",
+	}, []string{"currency"})
+
+	syncDisagreements = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "sync",
+		Name:      "source_disagreements",
+		Help:      "Source values that didn't match the rate the other sources agreed on in the last sync.",
+	}, []string{"currency"})
+
+	syncSourceFailures = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "sync",
+		Name:      "source_failed",
+		Help:      "1 when fetching the source failed in the last sync.",
+	}, []string{"source"})
+
+	syncFailovers = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "sync",
+		Name:      "failovers",
+		Help:      "Currencies synced from a fallback source in the last sync by the source used, none when every fallback failed.",
+	}, []string{"currency", "source"})
+
+	quarantinePending = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "sync",
+		Name:      "quarantine_pending",
+		Help:      "Quarantined rates waiting for review.",
+	})
+
+	syncFailures = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "sync",
+		Name:      "failures",
+		Help:      "Failures in the last sync by currency and stage.",
+	}, []string{"currency", "stage"})
+
+	syncUnchanged = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "sync",
+		Name:      "unchanged",
+		Help:      "1 when the last sync was skipped because the feed didn't change since the one before.",
+	})
+
+	// The publication time is exported instead of an age, an age pushed by the last run would
+	// stop growing while the syncer is down. Alert on time() minus it.
+	latestRate = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "sync",
+		Name:      "latest_rate_timestamp_seconds",
+		Help:      "Unix time of the newest stored publication by currency.",
+	}, []string{"currency"})
+
+	lastSync = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "sync",
+		Name:      "last_run_timestamp_seconds",
+		Help:      "Unix time of the last finished sync.",
+	})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests,
+		httpDuration,
+		syncFetchDuration,
+		syncInserted,
+		syncDuplicates,
+		syncQuarantined,
+		syncDisagreements,
+		syncSourceFailures,
+		syncFailovers,
+		quarantinePending,
+		syncFailures,
+		syncUnchanged,
+		latestRate,
+		lastSync,
+	)
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+// Middleware records request count and latency. Routes are labelled by their chi pattern,
+// so path parameters like the currency don't blow up the label cardinality.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ww := chimiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+		next.ServeHTTP(ww, r)
+
+		route := "unmatched"
+		if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+			route = rctx.RoutePattern()
+		}
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		labels := prometheus.Labels{"route": route, "method": r.Method, "status": strconv.Itoa(status)}
+		httpRequests.With(labels).Inc()
+		httpDuration.With(labels).Observe(time.Since(start).Seconds())
+	})
+}
+
+// RegisterDB exposes the connection pool statistics of db.
+func RegisterDB(db *sql.DB) {
+	Registry.MustRegister(collectors.NewDBStatsCollector(db, "backscreen"))
+}
+
+// RegisterCache exposes the hit and miss counters of a cache under the given name.
+func RegisterCache(name string, stats func() cache.Stats) {
+	labels := prometheus.Labels{"cache": name}
+
+	Registry.MustRegister(
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace:   namespace,
+			Subsystem:   "cache",
+			Name:        "hits_total",
+			Help:        "Cache hits.",
+			ConstLabels: labels,
+		}, func() float64 { return float64(stats().Hits) }),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace:   namespace,
+			Subsystem:   "cache",
+			Name:        "misses_total",
+			Help:        "Cache misses.",
+			ConstLabels: labels,
+		}, func() float64 { return float64(stats().Misses) }),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace:   namespace,
+			Subsystem:   "cache",
+			Name:        "evictions_total",
+			Help:        "Entries evicted because the cache was full.",
+			ConstLabels: labels,
+		}, func() float64 { return float64(stats().Evictions) }),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "cache",
+			Name:        "entries",
+			Help:        "Entries currently in the cache.",
+			ConstLabels: labels,
+		}, func() float64 { return float64(stats().Entries) }),
+	)
+}
+
+// SyncSource is the outcome of fetching a single source.
+type SyncSource struct {
+	Source        string
+	FetchDuration time.Duration
+	Failed        bool
+}
+
+// SyncCurrency is the outcome of syncing a single currency.
+type SyncCurrency struct {
+	Currency      string
+	Inserted      int
+	Duplicates    int
+	Quarantined   int
+	Disagreements int
+	// Failed counts the rates that could not be stored.
+	Failed      int
+	FetchFailed bool
+	// LatestPublishedAt is the newest stored publication date, zero when unknown.
+	LatestPublishedAt time.Time
+}
+
+// SyncFailover is a currency synced from a fallback source, Source is empty when every fallback failed.
+type SyncFailover struct {
+	Currency string
+	Source   string
+}
+
+// SyncRun is the outcome of a sync run.
+type SyncRun struct {
+	Sources       []SyncSource
+	Currencies    []SyncCurrency
+	Failovers     []SyncFailover
+	Unchanged     bool
+	PendingReview int
+}
+
+// ObserveSync records the outcome of a sync run, replacing the values of the previous run.
+func ObserveSync(run SyncRun) {
+	now := time.Now()
+
+	for _, vec := range []*prometheus.GaugeVec{
+		syncFetchDuration, syncSourceFailures, syncInserted, syncDuplicates,
+		syncQuarantined, syncDisagreements, syncFailures, syncFailovers, latestRate,
+	} {
+		vec.Reset()
+	}
+
+	for _, s := range run.Sources {
+		syncFetchDuration.WithLabelValues(s.Source).Set(s.FetchDuration.Seconds())
+		syncSourceFailures.WithLabelValues(s.Source).Set(boolValue(s.Failed))
+	}
+
+	for _, c := range run.Currencies {
+		syncInserted.WithLabelValues(c.Currency).Set(float64(c.Inserted))
+		syncDuplicates.WithLabelValues(c.Currency).Set(float64(c.Duplicates))
+		syncQuarantined.WithLabelValues(c.Currency).Set(float64(c.Quarantined))
+		syncDisagreements.WithLabelValues(c.Currency).Set(float64(c.Disagreements))
+		syncFailures.WithLabelValues(c.Currency, "fetch").Set(boolValue(c.FetchFailed))
+		syncFailures.WithLabelValues(c.Currency, "store").Set(float64(c.Failed))
+
+		if !c.LatestPublishedAt.IsZero() {
+			latestRate.WithLabelValues(c.Currency).Set(float64(c.LatestPublishedAt.Unix()))
+		}
+	}
+
+	for _, f := range run.Failovers {
+		source := f.Source
+		if source == "" {
+			source = "none"
+		}
+		syncFailovers.WithLabelValues(f.Currency, source).Inc()
+	}
+
+	syncUnchanged.Set(boolValue(run.Unchanged))
+	quarantinePending.Set(float64(run.PendingReview))
+
+	lastSync.Set(float64(now.Unix()))
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// Push sends the metrics to a Prometheus Pushgateway. The sync command is too short-lived to be scraped.
+func Push(ctx context.Context, url, job string) error {
+	if err := push.New(url, job).Gatherer(Registry).PushContext(ctx); err != nil {
+		return fmt.Errorf("failed to push metrics: %w", err)
+	}
+	return nil
+}
+
+// WriteTextfile writes the metrics to a file for the node exporter textfile collector.
+func WriteTextfile(path string) error {
+	if err := prometheus.WriteToTextfile(path, Registry); err != nil {
+		return fmt.Errorf("failed to write metrics textfile: %w", err)
+	}
+	return nil
+}
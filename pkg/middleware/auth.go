@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/zemzale/backscreen-home/domain/entity"
+	"github.com/zemzale/backscreen-home/domain/usecase/apikeys"
+	"github.com/zemzale/backscreen-home/pkg/server"
+)
+
+const APIKeyHeader = "X-API-Key"
+
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (entity.APIKey, error)
+}
+
+type apiKeyContextKey struct{}
+
+// APIKeyFromContext returns the key that authenticated the request, if any.
+func APIKeyFromContext(ctx context.Context) (entity.APIKey, bool) {
+	key, ok := ctx.Value(apiKeyContextKey{}).(entity.APIKey)
+	return key, ok
+}
+
+// APIKeyAuth enforces the scopes that the generated server puts into the request context
+// for operations declaring the ApiKeyAuth security scheme. Operations without it are left open.
+func APIKeyAuth(auth Authenticator) server.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			scopes, ok := ctx.Value(server.ApiKeyAuthScopes).([]string)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			logger := slog.With("component", "auth")
+
+			token := r.Header.Get(APIKeyHeader)
+			if token == "" {
+				writeError(w, http.StatusUnauthorized, "missing api key")
+				return
+			}
+
+			key, err := auth.Authenticate(ctx, token)
+			if err != nil {
+				if errors.Is(err, apikeys.ErrInvalidKey) {
+					logger.DebugContext(ctx, "Rejected invalid API key")
+					writeError(w, http.StatusUnauthorized, "invalid api key")
+					return
+				}
+
+				logger.ErrorContext(ctx, "Failed to authenticate API key", slog.String("err", err.Error()))
+				writeError(w, http.StatusInternalServerError, "failed to authenticate api key")
+				return
+			}
+
+			for _, scope := range scopes {
+				if !key.HasScope(entity.Scope(scope)) {
+					logger.DebugContext(ctx, "API key lacks scope", slog.Int("key_id", key.ID), slog.String("scope", scope))
+					writeError(w, http.StatusForbidden, "api key lacks the "+scope+" scope")
+					return
+				}
+			}
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, apiKeyContextKey{}, key)))
+		})
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(server.Error{Error: &msg})
+}
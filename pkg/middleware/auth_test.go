@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/zemzale/backscreen-home/domain/entity"
+	"github.com/zemzale/backscreen-home/domain/usecase/apikeys"
+	"github.com/zemzale/backscreen-home/pkg/server"
+)
+
+type fakeAuthenticator map[string]entity.APIKey
+
+func (f fakeAuthenticator) Authenticate(_ context.Context, token string) (entity.APIKey, error) {
+	key, ok := f[token]
+	if !ok {
+		return entity.APIKey{}, apikeys.ErrInvalidKey
+	}
+	return key, nil
+}
+
+func TestAPIKeyAuth(t *testing.T) {
+	auth := fakeAuthenticator{
+		"reader":   {ID: 1, Scopes: []entity.Scope{entity.ScopeRead}},
+		"exporter": {ID: 2, Scopes: []entity.Scope{entity.ScopeExport}},
+		"admin":    {ID: 3, Scopes: []entity.Scope{entity.ScopeAdmin}},
+	}
+
+	handler := APIKeyAuth(auth)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := APIKeyFromContext(r.Context()); !ok {
+			t.Error("Expected API key in request context")
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name   string
+		scopes []string
+		token  string
+		want   int
+	}{
+		{name: "missing key", scopes: []string{"read"}, want: http.StatusUnauthorized},
+		{name: "unknown key", scopes: []string{"read"}, token: "nope", want: http.StatusUnauthorized},
+		{name: "matching scope", scopes: []string{"read"}, token: "reader", want: http.StatusOK},
+		{name: "missing scope", scopes: []string{"read"}, token: "exporter", want: http.StatusForbidden},
+		{name: "admin has every scope", scopes: []string{"export"}, token: "admin", want: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req = req.WithContext(context.WithValue(req.Context(), server.ApiKeyAuthScopes, tt.scopes))
+			if tt.token != "" {
+				req.Header.Set(APIKeyHeader, tt.token)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("Expected status %d, got %d", tt.want, rec.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+package middleware
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/zemzale/backscreen-home/pkg/server"
+)
+
+// Limit describes a token bucket that refills at Rate tokens per second and holds at most Burst tokens.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// ParseLimit parses a limit in the "<rate>:<burst>" form, e.g. "0.5:10".
+func ParseLimit(s string) (Limit, error) {
+	rateStr, burstStr, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if !ok {
+		return Limit{}, fmt.Errorf("invalid limit %q, expected <rate>:<burst>", s)
+	}
+
+	rate, err := strconv.ParseFloat(rateStr, 64)
+	if err != nil || rate <= 0 {
+		return Limit{}, fmt.Errorf("invalid rate in limit %q", s)
+	}
+
+	burst, err := strconv.Atoi(burstStr)
+	if err != nil || burst <= 0 {
+		return Limit{}, fmt.Errorf("invalid burst in limit %q", s)
+	}
+
+	return Limit{Rate: rate, Burst: burst}, nil
+}
+
+// ParseRouteLimits parses comma separated "<route>=<rate>:<burst>" pairs, where route is
+// the chi route pattern, e.g. "/api/v1/{currency}/history=1:5".
+func ParseRouteLimits(s string) (map[string]Limit, error) {
+	limits := map[string]Limit{}
+
+	for pair := range strings.SplitSeq(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		route, limitStr, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid route limit %q, expected <route>=<rate>:<burst>", pair)
+		}
+
+		limit, err := ParseLimit(limitStr)
+		if err != nil {
+			return nil, err
+		}
+
+		limits[strings.TrimSpace(route)] = limit
+	}
+
+	return limits, nil
+}
+
+type Decision struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is the time until the bucket is full again.
+	Reset time.Duration
+	// RetryAfter is the time until the next token is available, zero when allowed.
+	RetryAfter time.Duration
+}
+
+// Limiter takes a token for the key. It is an interface so a shared store, like Redis,
+// can replace the in-process implementation when the API runs in multiple replicas.
+type Limiter interface {
+	Take(key string, limit Limit) Decision
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	limit   Limit
+}
+
+// MemoryLimiter is an in-process token bucket limiter.
+type MemoryLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// sweepInterval controls how often idle buckets are dropped, so the map doesn't grow with every client seen.
+const sweepInterval = time.Minute
+
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{
+		buckets: map[string]*bucket{},
+		now:     time.Now,
+	}
+}
+
+func (l *MemoryLimiter) Take(key string, limit Limit) Decision {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), updated: now}
+		l.buckets[key] = b
+	}
+	b.limit = limit
+
+	b.tokens = math.Min(float64(limit.Burst), b.tokens+now.Sub(b.updated).Seconds()*limit.Rate)
+	b.updated = now
+
+	decision := Decision{Limit: limit.Burst}
+	if b.tokens >= 1 {
+		b.tokens--
+		decision.Allowed = true
+	} else {
+		decision.RetryAfter = secondsToDuration((1 - b.tokens) / limit.Rate)
+	}
+
+	decision.Remaining = int(b.tokens)
+	decision.Reset = secondsToDuration((float64(limit.Burst) - b.tokens) / limit.Rate)
+
+	return decision
+}
+
+func (l *MemoryLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	// A bucket that has been idle long enough to refill completely is the same as a new one.
+	for key, b := range l.buckets {
+		if now.Sub(b.updated) > secondsToDuration(float64(b.limit.Burst)/b.limit.Rate) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// RateLimit limits requests per route and client. Clients are identified by their API key
+// when the request was authenticated and by their IP address otherwise, so it has to run
+// after APIKeyAuth.
+func RateLimit(limiter Limiter, defaultLimit Limit, routeLimits map[string]Limit) server.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			route := r.URL.Path
+			if rctx := chi.RouteContext(ctx); rctx != nil && rctx.RoutePattern() != "" {
+				route = rctx.RoutePattern()
+			}
+
+			limit, ok := routeLimits[route]
+			if !ok {
+				limit = defaultLimit
+			}
+
+			client := "ip:" + clientIP(r)
+			if key, ok := APIKeyFromContext(ctx); ok {
+				client = "key:" + strconv.Itoa(key.ID)
+			}
+
+			if !take(w, r, limiter, route+"|"+client, limit) {
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// IPRateLimit limits requests per IP address across all routes. It has to run before
+// APIKeyAuth, so requests with missing or invalid keys are limited before they cost
+// a key lookup.
+func IPRateLimit(limiter Limiter, limit Limit) server.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !take(w, r, limiter, "*|ip:"+clientIP(r), limit) {
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// take takes a token for the key and sets the rate limit headers. It writes the 429
+// response and returns false when the request is over the limit.
+func take(w http.ResponseWriter, r *http.Request, limiter Limiter, key string, limit Limit) bool {
+	decision := limiter.Take(key, limit)
+
+	header := w.Header()
+	header.Set("RateLimit-Limit", strconv.Itoa(decision.Limit))
+	header.Set("RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+	header.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(decision.Reset)))
+
+	if !decision.Allowed {
+		slog.With("component", "ratelimit").DebugContext(r.Context(),
+			"Rate limit exceeded",
+			slog.String("key", key),
+		)
+
+		header.Set("Retry-After", strconv.Itoa(ceilSeconds(decision.RetryAfter)))
+		writeError(w, http.StatusTooManyRequests, "rate limit exceeded")
+		return false
+	}
+
+	return true
+}
+
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
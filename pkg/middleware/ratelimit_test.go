@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/zemzale/backscreen-home/domain/entity"
+	"github.com/zemzale/backscreen-home/pkg/server"
+)
+
+func TestMemoryLimiter(t *testing.T) {
+	now := time.Date(2025, time.October, 10, 0, 0, 0, 0, time.UTC)
+	limiter := NewMemoryLimiter()
+	limiter.now = func() time.Time { return now }
+
+	limit := Limit{Rate: 1, Burst: 2}
+
+	for i := range 2 {
+		if d := limiter.Take("client", limit); !d.Allowed {
+			t.Fatalf("Expected request %d to be allowed", i)
+		}
+	}
+
+	d := limiter.Take("client", limit)
+	if d.Allowed {
+		t.Fatal("Expected request over the burst to be rejected")
+	}
+	if d.RetryAfter != time.Second {
+		t.Errorf("Expected retry after 1s, got %s", d.RetryAfter)
+	}
+	if d.Remaining != 0 {
+		t.Errorf("Expected 0 remaining, got %d", d.Remaining)
+	}
+
+	if d := limiter.Take("other", limit); !d.Allowed {
+		t.Error("Expected other client to have its own bucket")
+	}
+
+	now = now.Add(time.Second)
+	if d := limiter.Take("client", limit); !d.Allowed {
+		t.Error("Expected request to be allowed after refill")
+	}
+}
+
+func TestParseRouteLimits(t *testing.T) {
+	limits, err := ParseRouteLimits("/api/v1/{currency}/history=1:10, /api/v1/export=0.1:2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := limits["/api/v1/{currency}/history"]; got != (Limit{Rate: 1, Burst: 10}) {
+		t.Errorf("Expected history limit 1:10, got %+v", got)
+	}
+	if got := limits["/api/v1/export"]; got != (Limit{Rate: 0.1, Burst: 2}) {
+		t.Errorf("Expected export limit 0.1:2, got %+v", got)
+	}
+
+	if _, err := ParseRouteLimits("/api/v1/export=fast"); err == nil {
+		t.Error("Expected error for malformed limit")
+	}
+}
+
+func TestIPRateLimitBeforeAuth(t *testing.T) {
+	limiter := NewMemoryLimiter()
+	auth := fakeAuthenticator{"reader": {ID: 1, Scopes: []entity.Scope{entity.ScopeRead}}}
+
+	// The same order the generated server runs them in.
+	handler := IPRateLimit(limiter, Limit{Rate: 1, Burst: 2})(
+		APIKeyAuth(auth)(
+			RateLimit(limiter, Limit{Rate: 1, Burst: 5}, nil)(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusOK)
+				}),
+			),
+		),
+	)
+
+	want := []int{http.StatusUnauthorized, http.StatusUnauthorized, http.StatusTooManyRequests}
+	for i, status := range want {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/USD", nil)
+		req = req.WithContext(context.WithValue(req.Context(), server.ApiKeyAuthScopes, []string{"read"}))
+		req.Header.Set(APIKeyHeader, "bad")
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != status {
+			t.Errorf("Expected request %d with a bad key to get %d, got %d", i, status, rec.Code)
+		}
+	}
+}
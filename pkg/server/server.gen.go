@@ -7,49 +7,355 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/oapi-codegen/runtime"
 	strictnethttp "github.com/oapi-codegen/runtime/strictmiddleware/nethttp"
+	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+const (
+	ApiKeyAuthScopes = "ApiKeyAuth.Scopes"
+)
+
+// Defines values for QuarantinedRateStatus.
+const (
+	QuarantinedRateStatusApproved QuarantinedRateStatus = "approved"
+	QuarantinedRateStatusPending  QuarantinedRateStatus = "pending"
+	QuarantinedRateStatusRejected QuarantinedRateStatus = "rejected"
+)
+
+// Defines values for RateType.
+const (
+	Buy       RateType = "buy"
+	Reference RateType = "reference"
+	Sell      RateType = "sell"
+)
+
+// Defines values for Format.
+const (
+	FormatCsv  Format = "csv"
+	FormatJson Format = "json"
+	FormatXml  Format = "xml"
+)
+
+// Defines values for GetApiV1AdminQuarantineParamsStatus.
+const (
+	GetApiV1AdminQuarantineParamsStatusApproved GetApiV1AdminQuarantineParamsStatus = "approved"
+	GetApiV1AdminQuarantineParamsStatusPending  GetApiV1AdminQuarantineParamsStatus = "pending"
+	GetApiV1AdminQuarantineParamsStatusRejected GetApiV1AdminQuarantineParamsStatus = "rejected"
+)
+
+// Defines values for GetApiV1ExportParamsFormat.
+const (
+	GetApiV1ExportParamsFormatCsv     GetApiV1ExportParamsFormat = "csv"
+	GetApiV1ExportParamsFormatNdjson  GetApiV1ExportParamsFormat = "ndjson"
+	GetApiV1ExportParamsFormatParquet GetApiV1ExportParamsFormat = "parquet"
+)
+
+// Defines values for GetApiV1CurrencyParamsFormat.
+const (
+	GetApiV1CurrencyParamsFormatCsv  GetApiV1CurrencyParamsFormat = "csv"
+	GetApiV1CurrencyParamsFormatJson GetApiV1CurrencyParamsFormat = "json"
+	GetApiV1CurrencyParamsFormatXml  GetApiV1CurrencyParamsFormat = "xml"
+)
+
+// Defines values for GetApiV1CurrencyHistoryParamsFormat.
+const (
+	GetApiV1CurrencyHistoryParamsFormatCsv  GetApiV1CurrencyHistoryParamsFormat = "csv"
+	GetApiV1CurrencyHistoryParamsFormatJson GetApiV1CurrencyHistoryParamsFormat = "json"
+	GetApiV1CurrencyHistoryParamsFormatXml  GetApiV1CurrencyHistoryParamsFormat = "xml"
+)
+
+// Error defines model for Error.
+type Error struct {
+	Error *string `json:"error,omitempty"`
+}
+
+// LogLevels defines model for LogLevels.
+type LogLevels struct {
+	Components *map[string]string `json:"components,omitempty"`
+	Level      *string            `json:"level,omitempty"`
+}
+
+// QuarantineReview defines model for QuarantineReview.
+type QuarantineReview struct {
+	// Reason Why the rate is approved or rejected.
+	Reason string `json:"reason"`
+}
+
+// QuarantinedRate defines model for QuarantinedRate.
+type QuarantinedRate struct {
+	CreatedAt time.Time `json:"created_at"`
+	Id        int       `json:"id"`
+	Rate      Rate      `json:"rate"`
+
+	// Reason The sanity checks the rate failed.
+	Reason       string                `json:"reason"`
+	ReviewReason *string               `json:"review_reason,omitempty"`
+	ReviewedAt   *time.Time            `json:"reviewed_at,omitempty"`
+	ReviewedBy   *string               `json:"reviewed_by,omitempty"`
+	Source       string                `json:"source"`
+	Status       QuarantinedRateStatus `json:"status"`
+}
+
+// QuarantinedRateStatus defines model for QuarantinedRate.Status.
+type QuarantinedRateStatus string
+
 // Rate defines model for Rate.
 type Rate struct {
-	Code        string    `json:"code"`
+	// Base Currency the rate is quoted against, one base buys value of code.
+	Base string `json:"base"`
+	Code string `json:"code"`
+
+	// Computed The rate was computed from the EUR rates of code and base, rounded to 6 significant digits half to even.
+	Computed *bool `json:"computed,omitempty"`
+
+	// Date ECB reference date of the rate.
+	Date openapi_types.Date `json:"date"`
+
+	// PublishedAt When the source published the rate, in UTC.
+	PublishedAt time.Time `json:"published_at"`
+
+	// Source The source the rate was synced from, e.g. a fallback when the primary source was down.
+	Source *string  `json:"source,omitempty"`
+	Type   RateType `json:"type"`
+	Value  string   `json:"value"`
+}
+
+// RateProvenance defines model for RateProvenance.
+type RateProvenance struct {
+	Base string `json:"base"`
+	Code string `json:"code"`
+
+	// Date ECB reference date of the rate.
+	Date    openapi_types.Date `json:"date"`
+	Rate    *Rate              `json:"rate,omitempty"`
+	Sources []SourceRate       `json:"sources"`
+	Type    RateType           `json:"type"`
+}
+
+// RateType defines model for RateType.
+type RateType string
+
+// ReviewConflict defines model for ReviewConflict.
+type ReviewConflict struct {
+	Error           string          `json:"error"`
+	QuarantinedRate QuarantinedRate `json:"quarantined_rate"`
+}
+
+// SourceRate defines model for SourceRate.
+type SourceRate struct {
+	// Agrees Whether the value matched the rate the sources agreed on, within the reconcile tolerance.
+	Agrees bool `json:"agrees"`
+
+	// PublishedAt When the source published the rate, in UTC.
 	PublishedAt time.Time `json:"published_at"`
+	Source      string    `json:"source"`
 	Value       string    `json:"value"`
 }
 
+// Base defines model for Base.
+type Base = string
+
+// Format defines model for Format.
+type Format string
+
+// QuarantineID defines model for QuarantineID.
+type QuarantineID = int
+
+// Type defines model for Type.
+type Type = string
+
+// BadRequest defines model for BadRequest.
+type BadRequest struct {
+	Error *string `json:"error,omitempty"`
+}
+
+// Forbidden defines model for Forbidden.
+type Forbidden = Error
+
 // InternalServerError defines model for InternalServerError.
 type InternalServerError struct {
 	Error *string `json:"error,omitempty"`
 }
 
+// TooManyRequests defines model for TooManyRequests.
+type TooManyRequests = Error
+
+// Unauthorized defines model for Unauthorized.
+type Unauthorized = Error
+
+// GetApiV1AdminQuarantineParams defines parameters for GetApiV1AdminQuarantine.
+type GetApiV1AdminQuarantineParams struct {
+	Status *GetApiV1AdminQuarantineParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// GetApiV1AdminQuarantineParamsStatus defines parameters for GetApiV1AdminQuarantine.
+type GetApiV1AdminQuarantineParamsStatus string
+
+// GetApiV1ExportParams defines parameters for GetApiV1Export.
+type GetApiV1ExportParams struct {
+	// Currency Currency codes to export. All currencies are exported when omitted.
+	Currency *[]string `form:"currency,omitempty" json:"currency,omitempty"`
+
+	// Base Only export rates against this base currency. All bases are exported when omitted.
+	Base *string `form:"base,omitempty" json:"base,omitempty"`
+
+	// Type Only export rates of this type, `reference`, `buy` or `sell`. All types are exported when omitted.
+	Type *string `form:"type,omitempty" json:"type,omitempty"`
+
+	// From Inclusive start of the publication date range.
+	From *openapi_types.Date `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Inclusive end of the publication date range.
+	To     *openapi_types.Date         `form:"to,omitempty" json:"to,omitempty"`
+	Format *GetApiV1ExportParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// GetApiV1ExportParamsFormat defines parameters for GetApiV1Export.
+type GetApiV1ExportParamsFormat string
+
+// GetApiV1CurrencyParams defines parameters for GetApiV1Currency.
+type GetApiV1CurrencyParams struct {
+	// Format Overrides the response format negotiated from the Accept header.
+	Format *GetApiV1CurrencyParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+
+	// Base Currency the rates are quoted against.
+	Base *Base `form:"base,omitempty" json:"base,omitempty"`
+
+	// Type Kind of rate, `reference`, `buy` or `sell`. The ECB publishes reference rates only.
+	Type *Type `form:"type,omitempty" json:"type,omitempty"`
+}
+
+// GetApiV1CurrencyParamsFormat defines parameters for GetApiV1Currency.
+type GetApiV1CurrencyParamsFormat string
+
+// GetApiV1CurrencyHistoryParams defines parameters for GetApiV1CurrencyHistory.
+type GetApiV1CurrencyHistoryParams struct {
+	// Format Overrides the response format negotiated from the Accept header.
+	Format *GetApiV1CurrencyHistoryParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+
+	// Base Currency the rates are quoted against.
+	Base *Base `form:"base,omitempty" json:"base,omitempty"`
+
+	// Type Kind of rate, `reference`, `buy` or `sell`. The ECB publishes reference rates only.
+	Type *Type `form:"type,omitempty" json:"type,omitempty"`
+}
+
+// GetApiV1CurrencyHistoryParamsFormat defines parameters for GetApiV1CurrencyHistory.
+type GetApiV1CurrencyHistoryParamsFormat string
+
+// GetApiV1CurrencySourcesParams defines parameters for GetApiV1CurrencySources.
+type GetApiV1CurrencySourcesParams struct {
+	// Date ECB reference date, defaults to the date of the latest rate.
+	Date *openapi_types.Date `form:"date,omitempty" json:"date,omitempty"`
+
+	// Base Currency the rates are quoted against.
+	Base *Base `form:"base,omitempty" json:"base,omitempty"`
+
+	// Type Kind of rate, `reference`, `buy` or `sell`. The ECB publishes reference rates only.
+	Type *Type `form:"type,omitempty" json:"type,omitempty"`
+}
+
+// PutApiV1AdminLogLevelJSONRequestBody defines body for PutApiV1AdminLogLevel for application/json ContentType.
+type PutApiV1AdminLogLevelJSONRequestBody = LogLevels
+
+// PostApiV1AdminQuarantineIdApproveJSONRequestBody defines body for PostApiV1AdminQuarantineIdApprove for application/json ContentType.
+type PostApiV1AdminQuarantineIdApproveJSONRequestBody = QuarantineReview
+
+// PostApiV1AdminQuarantineIdRejectJSONRequestBody defines body for PostApiV1AdminQuarantineIdReject for application/json ContentType.
+type PostApiV1AdminQuarantineIdRejectJSONRequestBody = QuarantineReview
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Get log levels
+	// (GET /api/v1/admin/log-level)
+	GetApiV1AdminLogLevel(w http.ResponseWriter, r *http.Request)
+	// Change log levels at runtime
+	// (PUT /api/v1/admin/log-level)
+	PutApiV1AdminLogLevel(w http.ResponseWriter, r *http.Request)
+	// List quarantined rates
+	// (GET /api/v1/admin/quarantine)
+	GetApiV1AdminQuarantine(w http.ResponseWriter, r *http.Request, params GetApiV1AdminQuarantineParams)
+	// Approve a quarantined rate
+	// (POST /api/v1/admin/quarantine/{id}/approve)
+	PostApiV1AdminQuarantineIdApprove(w http.ResponseWriter, r *http.Request, id QuarantineID)
+	// Reject a quarantined rate
+	// (POST /api/v1/admin/quarantine/{id}/reject)
+	PostApiV1AdminQuarantineIdReject(w http.ResponseWriter, r *http.Request, id QuarantineID)
+	// Export historical exchange rates
+	// (GET /api/v1/export)
+	GetApiV1Export(w http.ResponseWriter, r *http.Request, params GetApiV1ExportParams)
 	// Get latest exchange rate
 	// (GET /api/v1/{currency})
-	GetApiV1Currency(w http.ResponseWriter, r *http.Request, currency string)
+	GetApiV1Currency(w http.ResponseWriter, r *http.Request, currency string, params GetApiV1CurrencyParams)
 	// Get all historical exchange rates
 	// (GET /api/v1/{currency}/history)
-	GetApiV1CurrencyHistory(w http.ResponseWriter, r *http.Request, currency string)
+	GetApiV1CurrencyHistory(w http.ResponseWriter, r *http.Request, currency string, params GetApiV1CurrencyHistoryParams)
+	// Get the source values behind an exchange rate
+	// (GET /api/v1/{currency}/sources)
+	GetApiV1CurrencySources(w http.ResponseWriter, r *http.Request, currency string, params GetApiV1CurrencySourcesParams)
 }
 
 // Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
 
 type Unimplemented struct{}
 
+// Get log levels
+// (GET /api/v1/admin/log-level)
+func (_ Unimplemented) GetApiV1AdminLogLevel(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Change log levels at runtime
+// (PUT /api/v1/admin/log-level)
+func (_ Unimplemented) PutApiV1AdminLogLevel(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List quarantined rates
+// (GET /api/v1/admin/quarantine)
+func (_ Unimplemented) GetApiV1AdminQuarantine(w http.ResponseWriter, r *http.Request, params GetApiV1AdminQuarantineParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Approve a quarantined rate
+// (POST /api/v1/admin/quarantine/{id}/approve)
+func (_ Unimplemented) PostApiV1AdminQuarantineIdApprove(w http.ResponseWriter, r *http.Request, id QuarantineID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Reject a quarantined rate
+// (POST /api/v1/admin/quarantine/{id}/reject)
+func (_ Unimplemented) PostApiV1AdminQuarantineIdReject(w http.ResponseWriter, r *http.Request, id QuarantineID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Export historical exchange rates
+// (GET /api/v1/export)
+func (_ Unimplemented) GetApiV1Export(w http.ResponseWriter, r *http.Request, params GetApiV1ExportParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Get latest exchange rate
 // (GET /api/v1/{currency})
-func (_ Unimplemented) GetApiV1Currency(w http.ResponseWriter, r *http.Request, currency string) {
+func (_ Unimplemented) GetApiV1Currency(w http.ResponseWriter, r *http.Request, currency string, params GetApiV1CurrencyParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
 // Get all historical exchange rates
 // (GET /api/v1/{currency}/history)
-func (_ Unimplemented) GetApiV1CurrencyHistory(w http.ResponseWriter, r *http.Request, currency string) {
+func (_ Unimplemented) GetApiV1CurrencyHistory(w http.ResponseWriter, r *http.Request, currency string, params GetApiV1CurrencyHistoryParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the source values behind an exchange rate
+// (GET /api/v1/{currency}/sources)
+func (_ Unimplemented) GetApiV1CurrencySources(w http.ResponseWriter, r *http.Request, currency string, params GetApiV1CurrencySourcesParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -62,22 +368,70 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
-// GetApiV1Currency operation middleware
-func (siw *ServerInterfaceWrapper) GetApiV1Currency(w http.ResponseWriter, r *http.Request) {
+// GetApiV1AdminLogLevel operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1AdminLogLevel(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiV1AdminLogLevel(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutApiV1AdminLogLevel operation middleware
+func (siw *ServerInterfaceWrapper) PutApiV1AdminLogLevel(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutApiV1AdminLogLevel(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetApiV1AdminQuarantine operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1AdminQuarantine(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "currency" -------------
-	var currency string
+	ctx := r.Context()
 
-	err = runtime.BindStyledParameterWithOptions("simple", "currency", chi.URLParam(r, "currency"), &currency, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiV1AdminQuarantineParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "currency", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetApiV1Currency(w, r, currency)
+		siw.Handler.GetApiV1AdminQuarantine(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -87,22 +441,28 @@ func (siw *ServerInterfaceWrapper) GetApiV1Currency(w http.ResponseWriter, r *ht
 	handler.ServeHTTP(w, r)
 }
 
-// GetApiV1CurrencyHistory operation middleware
-func (siw *ServerInterfaceWrapper) GetApiV1CurrencyHistory(w http.ResponseWriter, r *http.Request) {
+// PostApiV1AdminQuarantineIdApprove operation middleware
+func (siw *ServerInterfaceWrapper) PostApiV1AdminQuarantineIdApprove(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "currency" -------------
-	var currency string
+	// ------------- Path parameter "id" -------------
+	var id QuarantineID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "currency", chi.URLParam(r, "currency"), &currency, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "currency", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
 		return
 	}
 
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetApiV1CurrencyHistory(w, r, currency)
+		siw.Handler.PostApiV1AdminQuarantineIdApprove(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -112,200 +472,1182 @@ func (siw *ServerInterfaceWrapper) GetApiV1CurrencyHistory(w http.ResponseWriter
 	handler.ServeHTTP(w, r)
 }
 
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
-}
+// PostApiV1AdminQuarantineIdReject operation middleware
+func (siw *ServerInterfaceWrapper) PostApiV1AdminQuarantineIdReject(w http.ResponseWriter, r *http.Request) {
 
-func (e *UnescapedCookieParamError) Error() string {
-	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
-}
+	var err error
 
-func (e *UnescapedCookieParamError) Unwrap() error {
-	return e.Err
-}
+	// ------------- Path parameter "id" -------------
+	var id QuarantineID
 
-type UnmarshalingParamError struct {
-	ParamName string
-	Err       error
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
 
-func (e *UnmarshalingParamError) Error() string {
-	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
-}
+	ctx := r.Context()
 
-func (e *UnmarshalingParamError) Unwrap() error {
-	return e.Err
-}
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"admin"})
 
-type RequiredParamError struct {
-	ParamName string
-}
+	r = r.WithContext(ctx)
 
-func (e *RequiredParamError) Error() string {
-	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiV1AdminQuarantineIdReject(w, r, id)
+	}))
 
-type RequiredHeaderError struct {
-	ParamName string
-	Err       error
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-func (e *RequiredHeaderError) Error() string {
-	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+	handler.ServeHTTP(w, r)
 }
 
-func (e *RequiredHeaderError) Unwrap() error {
-	return e.Err
-}
+// GetApiV1Export operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1Export(w http.ResponseWriter, r *http.Request) {
 
-type InvalidParamFormatError struct {
-	ParamName string
-	Err       error
-}
+	var err error
 
-func (e *InvalidParamFormatError) Error() string {
-	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
-}
+	ctx := r.Context()
 
-func (e *InvalidParamFormatError) Unwrap() error {
-	return e.Err
-}
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"export"})
 
-type TooManyValuesForParamError struct {
-	ParamName string
-	Count     int
-}
+	r = r.WithContext(ctx)
 
-func (e *TooManyValuesForParamError) Error() string {
-	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiV1ExportParams
+
+	// ------------- Optional query parameter "currency" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "currency", r.URL.Query(), &params.Currency)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "currency", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "base" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "base", r.URL.Query(), &params.Base)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "base", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "type" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "type", r.URL.Query(), &params.Type)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "type", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiV1Export(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-// Handler creates http.Handler with routing matching OpenAPI spec.
-func Handler(si ServerInterface) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{})
+// GetApiV1Currency operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1Currency(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "currency" -------------
+	var currency string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "currency", chi.URLParam(r, "currency"), &currency, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "currency", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiV1CurrencyParams
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "base" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "base", r.URL.Query(), &params.Base)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "base", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "type" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "type", r.URL.Query(), &params.Type)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "type", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiV1Currency(w, r, currency, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetApiV1CurrencyHistory operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1CurrencyHistory(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "currency" -------------
+	var currency string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "currency", chi.URLParam(r, "currency"), &currency, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "currency", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiV1CurrencyHistoryParams
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "base" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "base", r.URL.Query(), &params.Base)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "base", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "type" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "type", r.URL.Query(), &params.Type)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "type", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiV1CurrencyHistory(w, r, currency, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetApiV1CurrencySources operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1CurrencySources(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "currency" -------------
+	var currency string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "currency", chi.URLParam(r, "currency"), &currency, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "currency", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiV1CurrencySourcesParams
+
+	// ------------- Optional query parameter "date" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "date", r.URL.Query(), &params.Date)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "date", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "base" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "base", r.URL.Query(), &params.Base)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "base", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "type" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "type", r.URL.Query(), &params.Type)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "type", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiV1CurrencySources(w, r, currency, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{})
+}
+
+type ChiServerOptions struct {
+	BaseURL          string
+	BaseRouter       chi.Router
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseRouter: r,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: r,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
+	r := options.BaseRouter
+
+	if r == nil {
+		r = chi.NewRouter()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/admin/log-level", wrapper.GetApiV1AdminLogLevel)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/v1/admin/log-level", wrapper.PutApiV1AdminLogLevel)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/admin/quarantine", wrapper.GetApiV1AdminQuarantine)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/admin/quarantine/{id}/approve", wrapper.PostApiV1AdminQuarantineIdApprove)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/admin/quarantine/{id}/reject", wrapper.PostApiV1AdminQuarantineIdReject)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/export", wrapper.GetApiV1Export)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/{currency}", wrapper.GetApiV1Currency)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/{currency}/history", wrapper.GetApiV1CurrencyHistory)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/{currency}/sources", wrapper.GetApiV1CurrencySources)
+	})
+
+	return r
+}
+
+type BadRequestJSONResponse struct {
+	Error *string `json:"error,omitempty"`
+}
+
+type ForbiddenJSONResponse Error
+
+type InternalServerErrorJSONResponse struct {
+	Error *string `json:"error,omitempty"`
+}
+
+type NotAcceptableResponse struct {
+}
+
+type NotFoundResponse struct {
+}
+
+type NotModifiedResponse struct {
+}
+
+type ReviewConflictJSONResponse ReviewConflict
+
+type TooManyRequestsResponseHeaders struct {
+	RateLimitLimit     int
+	RateLimitRemaining int
+	RateLimitReset     int
+	RetryAfter         int
+}
+type TooManyRequestsJSONResponse struct {
+	Body Error
+
+	Headers TooManyRequestsResponseHeaders
+}
+
+type UnauthorizedJSONResponse Error
+
+type GetApiV1AdminLogLevelRequestObject struct {
+}
+
+type GetApiV1AdminLogLevelResponseObject interface {
+	VisitGetApiV1AdminLogLevelResponse(w http.ResponseWriter) error
+}
+
+type GetApiV1AdminLogLevel200JSONResponse LogLevels
+
+func (response GetApiV1AdminLogLevel200JSONResponse) VisitGetApiV1AdminLogLevelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiV1AdminLogLevel401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetApiV1AdminLogLevel401JSONResponse) VisitGetApiV1AdminLogLevelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiV1AdminLogLevel403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response GetApiV1AdminLogLevel403JSONResponse) VisitGetApiV1AdminLogLevelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutApiV1AdminLogLevelRequestObject struct {
+	Body *PutApiV1AdminLogLevelJSONRequestBody
+}
+
+type PutApiV1AdminLogLevelResponseObject interface {
+	VisitPutApiV1AdminLogLevelResponse(w http.ResponseWriter) error
+}
+
+type PutApiV1AdminLogLevel200JSONResponse LogLevels
+
+func (response PutApiV1AdminLogLevel200JSONResponse) VisitPutApiV1AdminLogLevelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutApiV1AdminLogLevel400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response PutApiV1AdminLogLevel400JSONResponse) VisitPutApiV1AdminLogLevelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutApiV1AdminLogLevel401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response PutApiV1AdminLogLevel401JSONResponse) VisitPutApiV1AdminLogLevelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutApiV1AdminLogLevel403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response PutApiV1AdminLogLevel403JSONResponse) VisitPutApiV1AdminLogLevelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiV1AdminQuarantineRequestObject struct {
+	Params GetApiV1AdminQuarantineParams
+}
+
+type GetApiV1AdminQuarantineResponseObject interface {
+	VisitGetApiV1AdminQuarantineResponse(w http.ResponseWriter) error
+}
+
+type GetApiV1AdminQuarantine200JSONResponse []QuarantinedRate
+
+func (response GetApiV1AdminQuarantine200JSONResponse) VisitGetApiV1AdminQuarantineResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiV1AdminQuarantine400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response GetApiV1AdminQuarantine400JSONResponse) VisitGetApiV1AdminQuarantineResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiV1AdminQuarantine401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetApiV1AdminQuarantine401JSONResponse) VisitGetApiV1AdminQuarantineResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiV1AdminQuarantine403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response GetApiV1AdminQuarantine403JSONResponse) VisitGetApiV1AdminQuarantineResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiV1AdminQuarantine500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response GetApiV1AdminQuarantine500JSONResponse) VisitGetApiV1AdminQuarantineResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostApiV1AdminQuarantineIdApproveRequestObject struct {
+	Id   QuarantineID `json:"id"`
+	Body *PostApiV1AdminQuarantineIdApproveJSONRequestBody
+}
+
+type PostApiV1AdminQuarantineIdApproveResponseObject interface {
+	VisitPostApiV1AdminQuarantineIdApproveResponse(w http.ResponseWriter) error
+}
+
+type PostApiV1AdminQuarantineIdApprove200JSONResponse QuarantinedRate
+
+func (response PostApiV1AdminQuarantineIdApprove200JSONResponse) VisitPostApiV1AdminQuarantineIdApproveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostApiV1AdminQuarantineIdApprove400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response PostApiV1AdminQuarantineIdApprove400JSONResponse) VisitPostApiV1AdminQuarantineIdApproveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostApiV1AdminQuarantineIdApprove401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response PostApiV1AdminQuarantineIdApprove401JSONResponse) VisitPostApiV1AdminQuarantineIdApproveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostApiV1AdminQuarantineIdApprove403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response PostApiV1AdminQuarantineIdApprove403JSONResponse) VisitPostApiV1AdminQuarantineIdApproveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostApiV1AdminQuarantineIdApprove404Response = NotFoundResponse
+
+func (response PostApiV1AdminQuarantineIdApprove404Response) VisitPostApiV1AdminQuarantineIdApproveResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type PostApiV1AdminQuarantineIdApprove409JSONResponse struct{ ReviewConflictJSONResponse }
+
+func (response PostApiV1AdminQuarantineIdApprove409JSONResponse) VisitPostApiV1AdminQuarantineIdApproveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostApiV1AdminQuarantineIdApprove500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response PostApiV1AdminQuarantineIdApprove500JSONResponse) VisitPostApiV1AdminQuarantineIdApproveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostApiV1AdminQuarantineIdRejectRequestObject struct {
+	Id   QuarantineID `json:"id"`
+	Body *PostApiV1AdminQuarantineIdRejectJSONRequestBody
+}
+
+type PostApiV1AdminQuarantineIdRejectResponseObject interface {
+	VisitPostApiV1AdminQuarantineIdRejectResponse(w http.ResponseWriter) error
+}
+
+type PostApiV1AdminQuarantineIdReject200JSONResponse QuarantinedRate
+
+func (response PostApiV1AdminQuarantineIdReject200JSONResponse) VisitPostApiV1AdminQuarantineIdRejectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostApiV1AdminQuarantineIdReject400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response PostApiV1AdminQuarantineIdReject400JSONResponse) VisitPostApiV1AdminQuarantineIdRejectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostApiV1AdminQuarantineIdReject401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response PostApiV1AdminQuarantineIdReject401JSONResponse) VisitPostApiV1AdminQuarantineIdRejectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostApiV1AdminQuarantineIdReject403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response PostApiV1AdminQuarantineIdReject403JSONResponse) VisitPostApiV1AdminQuarantineIdRejectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostApiV1AdminQuarantineIdReject404Response = NotFoundResponse
+
+func (response PostApiV1AdminQuarantineIdReject404Response) VisitPostApiV1AdminQuarantineIdRejectResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type PostApiV1AdminQuarantineIdReject409JSONResponse struct{ ReviewConflictJSONResponse }
+
+func (response PostApiV1AdminQuarantineIdReject409JSONResponse) VisitPostApiV1AdminQuarantineIdRejectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostApiV1AdminQuarantineIdReject500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response PostApiV1AdminQuarantineIdReject500JSONResponse) VisitPostApiV1AdminQuarantineIdRejectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiV1ExportRequestObject struct {
+	Params GetApiV1ExportParams
+}
+
+type GetApiV1ExportResponseObject interface {
+	VisitGetApiV1ExportResponse(w http.ResponseWriter) error
+}
+
+type GetApiV1Export200ApplicationvndApacheParquetResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetApiV1Export200ApplicationvndApacheParquetResponse) VisitGetApiV1ExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/vnd.apache.parquet")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetApiV1Export200ApplicationxNdjsonResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetApiV1Export200ApplicationxNdjsonResponse) VisitGetApiV1ExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetApiV1Export200TextcsvResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetApiV1Export200TextcsvResponse) VisitGetApiV1ExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/csv")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetApiV1Export400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response GetApiV1Export400JSONResponse) VisitGetApiV1ExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiV1Export401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetApiV1Export401JSONResponse) VisitGetApiV1ExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiV1Export403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response GetApiV1Export403JSONResponse) VisitGetApiV1ExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiV1Export429JSONResponse struct{ TooManyRequestsJSONResponse }
+
+func (response GetApiV1Export429JSONResponse) VisitGetApiV1ExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetApiV1Export500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response GetApiV1Export500JSONResponse) VisitGetApiV1ExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiV1CurrencyRequestObject struct {
+	Currency string `json:"currency"`
+	Params   GetApiV1CurrencyParams
+}
+
+type GetApiV1CurrencyResponseObject interface {
+	VisitGetApiV1CurrencyResponse(w http.ResponseWriter) error
+}
+
+type GetApiV1Currency200JSONResponse Rate
+
+func (response GetApiV1Currency200JSONResponse) VisitGetApiV1CurrencyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiV1Currency200ApplicationxmlResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetApiV1Currency200ApplicationxmlResponse) VisitGetApiV1CurrencyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/xml")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetApiV1Currency200TextcsvResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetApiV1Currency200TextcsvResponse) VisitGetApiV1CurrencyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/csv")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetApiV1Currency304Response = NotModifiedResponse
+
+func (response GetApiV1Currency304Response) VisitGetApiV1CurrencyResponse(w http.ResponseWriter) error {
+	w.WriteHeader(304)
+	return nil
+}
+
+type GetApiV1Currency400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response GetApiV1Currency400JSONResponse) VisitGetApiV1CurrencyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiV1Currency401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetApiV1Currency401JSONResponse) VisitGetApiV1CurrencyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiV1Currency403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response GetApiV1Currency403JSONResponse) VisitGetApiV1CurrencyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiV1Currency404Response = NotFoundResponse
+
+func (response GetApiV1Currency404Response) VisitGetApiV1CurrencyResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type GetApiV1Currency406Response = NotAcceptableResponse
+
+func (response GetApiV1Currency406Response) VisitGetApiV1CurrencyResponse(w http.ResponseWriter) error {
+	w.WriteHeader(406)
+	return nil
+}
+
+type GetApiV1Currency429JSONResponse struct{ TooManyRequestsJSONResponse }
+
+func (response GetApiV1Currency429JSONResponse) VisitGetApiV1CurrencyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetApiV1Currency500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response GetApiV1Currency500JSONResponse) VisitGetApiV1CurrencyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiV1CurrencyHistoryRequestObject struct {
+	Currency string `json:"currency"`
+	Params   GetApiV1CurrencyHistoryParams
 }
 
-type ChiServerOptions struct {
-	BaseURL          string
-	BaseRouter       chi.Router
-	Middlewares      []MiddlewareFunc
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+type GetApiV1CurrencyHistoryResponseObject interface {
+	VisitGetApiV1CurrencyHistoryResponse(w http.ResponseWriter) error
 }
 
-// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
-func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseRouter: r,
-	})
+type GetApiV1CurrencyHistory200JSONResponse []Rate
+
+func (response GetApiV1CurrencyHistory200JSONResponse) VisitGetApiV1CurrencyHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseURL:    baseURL,
-		BaseRouter: r,
-	})
+type GetApiV1CurrencyHistory200ApplicationxmlResponse struct {
+	Body          io.Reader
+	ContentLength int64
 }
 
-// HandlerWithOptions creates http.Handler with additional options
-func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
-	r := options.BaseRouter
+func (response GetApiV1CurrencyHistory200ApplicationxmlResponse) VisitGetApiV1CurrencyHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/xml")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
 
-	if r == nil {
-		r = chi.NewRouter()
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
 	}
-	if options.ErrorHandlerFunc == nil {
-		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetApiV1CurrencyHistory200TextcsvResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetApiV1CurrencyHistory200TextcsvResponse) VisitGetApiV1CurrencyHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/csv")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
 	}
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
 	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
 
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/api/v1/{currency}", wrapper.GetApiV1Currency)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/api/v1/{currency}/history", wrapper.GetApiV1CurrencyHistory)
-	})
+type GetApiV1CurrencyHistory304Response = NotModifiedResponse
 
-	return r
+func (response GetApiV1CurrencyHistory304Response) VisitGetApiV1CurrencyHistoryResponse(w http.ResponseWriter) error {
+	w.WriteHeader(304)
+	return nil
 }
 
-type InternalServerErrorJSONResponse struct {
-	Error *string `json:"error,omitempty"`
-}
+type GetApiV1CurrencyHistory400JSONResponse struct{ BadRequestJSONResponse }
 
-type NotFoundResponse struct {
-}
+func (response GetApiV1CurrencyHistory400JSONResponse) VisitGetApiV1CurrencyHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
 
-type GetApiV1CurrencyRequestObject struct {
-	Currency string `json:"currency"`
+	return json.NewEncoder(w).Encode(response)
 }
 
-type GetApiV1CurrencyResponseObject interface {
-	VisitGetApiV1CurrencyResponse(w http.ResponseWriter) error
+type GetApiV1CurrencyHistory401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetApiV1CurrencyHistory401JSONResponse) VisitGetApiV1CurrencyHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type GetApiV1Currency200JSONResponse Rate
+type GetApiV1CurrencyHistory403JSONResponse struct{ ForbiddenJSONResponse }
 
-func (response GetApiV1Currency200JSONResponse) VisitGetApiV1CurrencyResponse(w http.ResponseWriter) error {
+func (response GetApiV1CurrencyHistory403JSONResponse) VisitGetApiV1CurrencyHistoryResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(403)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetApiV1Currency404Response = NotFoundResponse
+type GetApiV1CurrencyHistory404Response = NotFoundResponse
 
-func (response GetApiV1Currency404Response) VisitGetApiV1CurrencyResponse(w http.ResponseWriter) error {
+func (response GetApiV1CurrencyHistory404Response) VisitGetApiV1CurrencyHistoryResponse(w http.ResponseWriter) error {
 	w.WriteHeader(404)
 	return nil
 }
 
-type GetApiV1Currency500JSONResponse struct {
+type GetApiV1CurrencyHistory406Response = NotAcceptableResponse
+
+func (response GetApiV1CurrencyHistory406Response) VisitGetApiV1CurrencyHistoryResponse(w http.ResponseWriter) error {
+	w.WriteHeader(406)
+	return nil
+}
+
+type GetApiV1CurrencyHistory429JSONResponse struct{ TooManyRequestsJSONResponse }
+
+func (response GetApiV1CurrencyHistory429JSONResponse) VisitGetApiV1CurrencyHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetApiV1CurrencyHistory500JSONResponse struct {
 	InternalServerErrorJSONResponse
 }
 
-func (response GetApiV1Currency500JSONResponse) VisitGetApiV1CurrencyResponse(w http.ResponseWriter) error {
+func (response GetApiV1CurrencyHistory500JSONResponse) VisitGetApiV1CurrencyHistoryResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetApiV1CurrencyHistoryRequestObject struct {
+type GetApiV1CurrencySourcesRequestObject struct {
 	Currency string `json:"currency"`
+	Params   GetApiV1CurrencySourcesParams
 }
 
-type GetApiV1CurrencyHistoryResponseObject interface {
-	VisitGetApiV1CurrencyHistoryResponse(w http.ResponseWriter) error
+type GetApiV1CurrencySourcesResponseObject interface {
+	VisitGetApiV1CurrencySourcesResponse(w http.ResponseWriter) error
 }
 
-type GetApiV1CurrencyHistory200JSONResponse []Rate
+type GetApiV1CurrencySources200JSONResponse RateProvenance
 
-func (response GetApiV1CurrencyHistory200JSONResponse) VisitGetApiV1CurrencyHistoryResponse(w http.ResponseWriter) error {
+func (response GetApiV1CurrencySources200JSONResponse) VisitGetApiV1CurrencySourcesResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetApiV1CurrencyHistory404Response = NotFoundResponse
+type GetApiV1CurrencySources400JSONResponse struct{ BadRequestJSONResponse }
 
-func (response GetApiV1CurrencyHistory404Response) VisitGetApiV1CurrencyHistoryResponse(w http.ResponseWriter) error {
+func (response GetApiV1CurrencySources400JSONResponse) VisitGetApiV1CurrencySourcesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiV1CurrencySources401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetApiV1CurrencySources401JSONResponse) VisitGetApiV1CurrencySourcesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiV1CurrencySources403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response GetApiV1CurrencySources403JSONResponse) VisitGetApiV1CurrencySourcesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiV1CurrencySources404Response = NotFoundResponse
+
+func (response GetApiV1CurrencySources404Response) VisitGetApiV1CurrencySourcesResponse(w http.ResponseWriter) error {
 	w.WriteHeader(404)
 	return nil
 }
 
-type GetApiV1CurrencyHistory500JSONResponse struct {
+type GetApiV1CurrencySources429JSONResponse struct{ TooManyRequestsJSONResponse }
+
+func (response GetApiV1CurrencySources429JSONResponse) VisitGetApiV1CurrencySourcesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetApiV1CurrencySources500JSONResponse struct {
 	InternalServerErrorJSONResponse
 }
 
-func (response GetApiV1CurrencyHistory500JSONResponse) VisitGetApiV1CurrencyHistoryResponse(w http.ResponseWriter) error {
+func (response GetApiV1CurrencySources500JSONResponse) VisitGetApiV1CurrencySourcesResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
@@ -314,12 +1656,33 @@ func (response GetApiV1CurrencyHistory500JSONResponse) VisitGetApiV1CurrencyHist
 
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
+	// Get log levels
+	// (GET /api/v1/admin/log-level)
+	GetApiV1AdminLogLevel(ctx context.Context, request GetApiV1AdminLogLevelRequestObject) (GetApiV1AdminLogLevelResponseObject, error)
+	// Change log levels at runtime
+	// (PUT /api/v1/admin/log-level)
+	PutApiV1AdminLogLevel(ctx context.Context, request PutApiV1AdminLogLevelRequestObject) (PutApiV1AdminLogLevelResponseObject, error)
+	// List quarantined rates
+	// (GET /api/v1/admin/quarantine)
+	GetApiV1AdminQuarantine(ctx context.Context, request GetApiV1AdminQuarantineRequestObject) (GetApiV1AdminQuarantineResponseObject, error)
+	// Approve a quarantined rate
+	// (POST /api/v1/admin/quarantine/{id}/approve)
+	PostApiV1AdminQuarantineIdApprove(ctx context.Context, request PostApiV1AdminQuarantineIdApproveRequestObject) (PostApiV1AdminQuarantineIdApproveResponseObject, error)
+	// Reject a quarantined rate
+	// (POST /api/v1/admin/quarantine/{id}/reject)
+	PostApiV1AdminQuarantineIdReject(ctx context.Context, request PostApiV1AdminQuarantineIdRejectRequestObject) (PostApiV1AdminQuarantineIdRejectResponseObject, error)
+	// Export historical exchange rates
+	// (GET /api/v1/export)
+	GetApiV1Export(ctx context.Context, request GetApiV1ExportRequestObject) (GetApiV1ExportResponseObject, error)
 	// Get latest exchange rate
 	// (GET /api/v1/{currency})
 	GetApiV1Currency(ctx context.Context, request GetApiV1CurrencyRequestObject) (GetApiV1CurrencyResponseObject, error)
 	// Get all historical exchange rates
 	// (GET /api/v1/{currency}/history)
 	GetApiV1CurrencyHistory(ctx context.Context, request GetApiV1CurrencyHistoryRequestObject) (GetApiV1CurrencyHistoryResponseObject, error)
+	// Get the source values behind an exchange rate
+	// (GET /api/v1/{currency}/sources)
+	GetApiV1CurrencySources(ctx context.Context, request GetApiV1CurrencySourcesRequestObject) (GetApiV1CurrencySourcesResponseObject, error)
 }
 
 type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
@@ -351,11 +1714,185 @@ type strictHandler struct {
 	options     StrictHTTPServerOptions
 }
 
+// GetApiV1AdminLogLevel operation middleware
+func (sh *strictHandler) GetApiV1AdminLogLevel(w http.ResponseWriter, r *http.Request) {
+	var request GetApiV1AdminLogLevelRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetApiV1AdminLogLevel(ctx, request.(GetApiV1AdminLogLevelRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetApiV1AdminLogLevel")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetApiV1AdminLogLevelResponseObject); ok {
+		if err := validResponse.VisitGetApiV1AdminLogLevelResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PutApiV1AdminLogLevel operation middleware
+func (sh *strictHandler) PutApiV1AdminLogLevel(w http.ResponseWriter, r *http.Request) {
+	var request PutApiV1AdminLogLevelRequestObject
+
+	var body PutApiV1AdminLogLevelJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PutApiV1AdminLogLevel(ctx, request.(PutApiV1AdminLogLevelRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutApiV1AdminLogLevel")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PutApiV1AdminLogLevelResponseObject); ok {
+		if err := validResponse.VisitPutApiV1AdminLogLevelResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetApiV1AdminQuarantine operation middleware
+func (sh *strictHandler) GetApiV1AdminQuarantine(w http.ResponseWriter, r *http.Request, params GetApiV1AdminQuarantineParams) {
+	var request GetApiV1AdminQuarantineRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetApiV1AdminQuarantine(ctx, request.(GetApiV1AdminQuarantineRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetApiV1AdminQuarantine")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetApiV1AdminQuarantineResponseObject); ok {
+		if err := validResponse.VisitGetApiV1AdminQuarantineResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostApiV1AdminQuarantineIdApprove operation middleware
+func (sh *strictHandler) PostApiV1AdminQuarantineIdApprove(w http.ResponseWriter, r *http.Request, id QuarantineID) {
+	var request PostApiV1AdminQuarantineIdApproveRequestObject
+
+	request.Id = id
+
+	var body PostApiV1AdminQuarantineIdApproveJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostApiV1AdminQuarantineIdApprove(ctx, request.(PostApiV1AdminQuarantineIdApproveRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostApiV1AdminQuarantineIdApprove")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostApiV1AdminQuarantineIdApproveResponseObject); ok {
+		if err := validResponse.VisitPostApiV1AdminQuarantineIdApproveResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostApiV1AdminQuarantineIdReject operation middleware
+func (sh *strictHandler) PostApiV1AdminQuarantineIdReject(w http.ResponseWriter, r *http.Request, id QuarantineID) {
+	var request PostApiV1AdminQuarantineIdRejectRequestObject
+
+	request.Id = id
+
+	var body PostApiV1AdminQuarantineIdRejectJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostApiV1AdminQuarantineIdReject(ctx, request.(PostApiV1AdminQuarantineIdRejectRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostApiV1AdminQuarantineIdReject")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostApiV1AdminQuarantineIdRejectResponseObject); ok {
+		if err := validResponse.VisitPostApiV1AdminQuarantineIdRejectResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetApiV1Export operation middleware
+func (sh *strictHandler) GetApiV1Export(w http.ResponseWriter, r *http.Request, params GetApiV1ExportParams) {
+	var request GetApiV1ExportRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetApiV1Export(ctx, request.(GetApiV1ExportRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetApiV1Export")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetApiV1ExportResponseObject); ok {
+		if err := validResponse.VisitGetApiV1ExportResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetApiV1Currency operation middleware
-func (sh *strictHandler) GetApiV1Currency(w http.ResponseWriter, r *http.Request, currency string) {
+func (sh *strictHandler) GetApiV1Currency(w http.ResponseWriter, r *http.Request, currency string, params GetApiV1CurrencyParams) {
 	var request GetApiV1CurrencyRequestObject
 
 	request.Currency = currency
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
 		return sh.ssi.GetApiV1Currency(ctx, request.(GetApiV1CurrencyRequestObject))
@@ -378,10 +1915,11 @@ func (sh *strictHandler) GetApiV1Currency(w http.ResponseWriter, r *http.Request
 }
 
 // GetApiV1CurrencyHistory operation middleware
-func (sh *strictHandler) GetApiV1CurrencyHistory(w http.ResponseWriter, r *http.Request, currency string) {
+func (sh *strictHandler) GetApiV1CurrencyHistory(w http.ResponseWriter, r *http.Request, currency string, params GetApiV1CurrencyHistoryParams) {
 	var request GetApiV1CurrencyHistoryRequestObject
 
 	request.Currency = currency
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
 		return sh.ssi.GetApiV1CurrencyHistory(ctx, request.(GetApiV1CurrencyHistoryRequestObject))
@@ -402,3 +1940,30 @@ func (sh *strictHandler) GetApiV1CurrencyHistory(w http.ResponseWriter, r *http.
 		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
 	}
 }
+
+// GetApiV1CurrencySources operation middleware
+func (sh *strictHandler) GetApiV1CurrencySources(w http.ResponseWriter, r *http.Request, currency string, params GetApiV1CurrencySourcesParams) {
+	var request GetApiV1CurrencySourcesRequestObject
+
+	request.Currency = currency
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetApiV1CurrencySources(ctx, request.(GetApiV1CurrencySourcesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetApiV1CurrencySources")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetApiV1CurrencySourcesResponseObject); ok {
+		if err := validResponse.VisitGetApiV1CurrencySourcesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
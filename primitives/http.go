@@ -3,6 +3,8 @@ package primitives
 import (
 	"net/http"
 	"time"
+
+	"github.com/zemzale/backscreen-home/pkg/telemetry"
 )
 
 type HTTPClientOption func(*http.Client)
@@ -17,12 +19,12 @@ func WithTimeout(timeout time.Duration) HTTPClientOption {
 func NewHTTPClient(opts ...HTTPClientOption) *http.Client {
 	client := &http.Client{
 		Timeout: time.Second * 10,
-		Transport: &http.Transport{
+		Transport: telemetry.Transport(&http.Transport{
 			MaxIdleConns:          10,
 			IdleConnTimeout:       time.Second * 60,
 			MaxConnsPerHost:       10,
 			ResponseHeaderTimeout: time.Second * 10,
-		},
+		}),
 	}
 
 	for _, opt := range opts {
@@ -0,0 +1,125 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/zemzale/backscreen-home/domain/entity"
+	"github.com/zemzale/backscreen-home/slices"
+)
+
+const createAPIKeysTable = `
+CREATE TABLE IF NOT EXISTS api_keys (
+	id INT NOT NULL AUTO_INCREMENT,
+	name VARCHAR(255) NOT NULL,
+	prefix VARCHAR(16) NOT NULL,
+	hash CHAR(64) NOT NULL,
+	scopes VARCHAR(255) NOT NULL,
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	revoked_at DATETIME NULL,
+	PRIMARY KEY (id),
+	UNIQUE INDEX (hash)
+);`
+
+type APIKey struct {
+	ID        int          `db:"id"`
+	Name      string       `db:"name"`
+	Prefix    string       `db:"prefix"`
+	Scopes    string       `db:"scopes"`
+	CreatedAt time.Time    `db:"created_at"`
+	RevokedAt sql.NullTime `db:"revoked_at"`
+}
+
+func (k APIKey) ToEntity() entity.APIKey {
+	key := entity.APIKey{
+		ID:        k.ID,
+		Name:      k.Name,
+		Prefix:    k.Prefix,
+		CreatedAt: k.CreatedAt,
+	}
+
+	if k.Scopes != "" {
+		key.Scopes = slices.Map(strings.Split(k.Scopes, ","), func(s string) entity.Scope { return entity.Scope(s) })
+	}
+
+	if k.RevokedAt.Valid {
+		key.RevokedAt = &k.RevokedAt.Time
+	}
+
+	return key
+}
+
+// StoreAPIKey stores a new key. Only the hash of the key is persisted.
+func (c *Client) StoreAPIKey(ctx context.Context, key entity.APIKey, hash string) (entity.APIKey, error) {
+	scopes := slices.Map(key.Scopes, func(s entity.Scope) string { return string(s) })
+
+	result, err := c.db.ExecContext(ctx, `
+		INSERT INTO api_keys (name, prefix, hash, scopes) VALUES (?, ?, ?, ?);
+	`, key.Name, key.Prefix, hash, strings.Join(scopes, ","))
+	if err != nil {
+		return entity.APIKey{}, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return entity.APIKey{}, err
+	}
+
+	return c.getAPIKey(ctx, `WHERE id = ?`, id)
+}
+
+func (c *Client) GetAPIKeyByHash(ctx context.Context, hash string) (entity.APIKey, error) {
+	return c.getAPIKey(ctx, `WHERE hash = ?`, hash)
+}
+
+func (c *Client) getAPIKey(ctx context.Context, where string, args ...any) (entity.APIKey, error) {
+	var key APIKey
+
+	err := c.db.GetContext(ctx, &key, `
+		SELECT id, name, prefix, scopes, created_at, revoked_at FROM api_keys `+where+`;
+	`, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.APIKey{}, ErrNotFound
+		}
+		return entity.APIKey{}, err
+	}
+
+	return key.ToEntity(), nil
+}
+
+func (c *Client) ListAPIKeys(ctx context.Context) ([]entity.APIKey, error) {
+	var keys []APIKey
+
+	err := c.db.SelectContext(ctx, &keys, `
+		SELECT id, name, prefix, scopes, created_at, revoked_at FROM api_keys ORDER BY id;
+	`)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Map(keys, func(k APIKey) entity.APIKey { return k.ToEntity() }), nil
+}
+
+func (c *Client) RevokeAPIKey(ctx context.Context, id int) error {
+	result, err := c.db.ExecContext(ctx, `
+		UPDATE api_keys SET revoked_at = CURRENT_TIMESTAMP WHERE id = ? AND revoked_at IS NULL;
+	`, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package storage
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/zemzale/backscreen-home/domain/entity"
+	"github.com/zemzale/backscreen-home/pkg/cache"
+)
+
+// CachedReader is a read-through cache in front of the rate reads of the Client.
+// It is purged when rates are stored through the same Client, and when Watch notices
+// that another process changed the rates.
+type CachedReader struct {
+	client  *Client
+	latest  *cache.Cache[entity.Series, entity.Rate]
+	history *cache.Cache[entity.Series, []entity.Rate]
+}
+
+func NewCachedReader(client *Client, size int, ttl time.Duration) *CachedReader {
+	r := &CachedReader{
+		client:  client,
+		latest:  cache.New[entity.Series, entity.Rate](size, ttl),
+		history: cache.New[entity.Series, []entity.Rate](size, ttl),
+	}
+
+	client.OnRatesChanged(r.Purge)
+
+	return r
+}
+
+func (r *CachedReader) GetLatestRate(ctx context.Context, series entity.Series) (entity.Rate, error) {
+	if rate, ok := r.latest.Get(series); ok {
+		return rate, nil
+	}
+
+	// Rates stored while loading purge the cache, the loaded rate may be stale then.
+	generation := r.latest.Generation()
+	rate, err := r.client.GetLatestRate(ctx, series)
+	if err != nil {
+		return entity.Rate{}, err
+	}
+
+	r.latest.SetIfGeneration(series, rate, generation)
+
+	return rate, nil
+}
+
+func (r *CachedReader) GetRates(ctx context.Context, series entity.Series) ([]entity.Rate, error) {
+	if rates, ok := r.history.Get(series); ok {
+		return rates, nil
+	}
+
+	generation := r.history.Generation()
+	rates, err := r.client.GetRates(ctx, series)
+	if err != nil {
+		return nil, err
+	}
+
+	r.history.SetIfGeneration(series, rates, generation)
+
+	return rates, nil
+}
+
+func (r *CachedReader) Purge() {
+	r.latest.Purge()
+	r.history.Purge()
+}
+
+// Stats returns the cache statistics of the latest and history caches.
+func (r *CachedReader) Stats() (latest cache.Stats, history cache.Stats) {
+	return r.latest.Stats(), r.history.Stats()
+}
+
+// Watch polls the rates marker and purges the cache when it changes, which covers
+// deployments where the syncer runs in a different process. It blocks until ctx is done.
+func (r *CachedReader) Watch(ctx context.Context, interval time.Duration) {
+	logger := slog.With("component", "cache")
+
+	last, err := r.client.GetRatesMarker(ctx)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to read rates marker", slog.Any("error", err))
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		marker, err := r.client.GetRatesMarker(ctx)
+		if err != nil {
+			logger.ErrorContext(ctx, "Failed to read rates marker", slog.Any("error", err))
+			continue
+		}
+
+		if !marker.Equal(last) {
+			logger.DebugContext(ctx, "Rates changed, purging cache", slog.Int("count", marker.Count))
+			r.Purge()
+			last = marker
+		}
+	}
+}
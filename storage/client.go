@@ -4,12 +4,16 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"log/slog"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-sql-driver/mysql"
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/jmoiron/sqlx"
+	"github.com/zemzale/backscreen-home/domain/ecb"
 	"github.com/zemzale/backscreen-home/domain/entity"
 	"github.com/zemzale/backscreen-home/slices"
 )
@@ -28,41 +32,82 @@ CREATE TABLE IF NOT EXISTS rates (
 	INDEX (published_at)
 );`
 
-const createImportDataTable = `
-CREATE TABLE IF NOT EXISTS import_data (
-	id INT NOT NULL AUTO_INCREMENT,
-	data TEXT NOT NULL,
-	source VARCHAR(255) NOT NULL,
-	created_at DATETIME NOT NULL,
-	updated_at DATETIME NOT NULL,
-	PRIMARY KEY (id)
-);
-`
-
 var schemas = []string{
 	createRatesTable,
 	createImportDataTable,
+	createAPIKeysTable,
+	createSchemaMigrationsTable,
+	createQuarantinedRatesTable,
+	createRateSourcesTable,
 }
 
 type Rate struct {
-	ID          int       `db:"id"`
-	Code        string    `db:"code"`
-	Value       string    `db:"value"`
-	PunlishedAt time.Time `db:"published_at"`
+	ID    int    `db:"id"`
+	Code  string `db:"code"`
+	Value string `db:"value"`
+	// RateDate is the ECB reference date, PublishedAt is stored in UTC.
+	RateDate    time.Time `db:"rate_date"`
+	PublishedAt time.Time `db:"published_at"`
+	Base        string    `db:"base"`
+	RateType    string    `db:"rate_type"`
+	Source      string    `db:"source"`
 	CreatedAt   time.Time `db:"created_at"`
 	UpdatedAt   time.Time `db:"updated_at"`
 }
 
 func (r Rate) ToEntity() entity.Rate {
 	return entity.Rate{
+		Base:        r.Base,
 		Code:        r.Code,
 		Value:       r.Value,
-		PublishedAt: r.PunlishedAt,
+		Type:        entity.RateType(r.RateType),
+		Date:        r.RateDate.UTC(),
+		PublishedAt: r.PublishedAt.UTC(),
+		Source:      r.Source,
 	}
 }
 
+const rateColumns = `code, value, rate_date, published_at, base, rate_type, source`
+
+// rateArgs returns the insert arguments of the rate for the columns code, value, rate_date,
+// published_at, base and rate_type. The reference date, base and type of the ECB reference
+// rates are filled in when the source didn't set them.
+func rateArgs(rate entity.Rate) []any {
+	date := rate.Date
+	if date.IsZero() {
+		date = ecb.ReferenceDate(rate.PublishedAt)
+	}
+
+	base := rate.Base
+	if base == "" {
+		base = entity.DefaultBase
+	}
+
+	rateType := rate.Type
+	if rateType == "" {
+		rateType = entity.RateTypeReference
+	}
+
+	return []any{rate.Code, rate.Value, date.Format(time.DateOnly), rate.PublishedAt.UTC(), base, string(rateType)}
+}
+
+// seriesCondition matches the rows of a series, with seriesArgs as its arguments.
+const seriesCondition = `base = ? AND code = ? AND rate_type = ?`
+
+func seriesArgs(series entity.Series) []any {
+	return []any{series.Base, series.Code, string(series.Type)}
+}
+
+// storedRateArgs returns the insert arguments of a row in rates, which also records the source.
+func storedRateArgs(rate entity.Rate) []any {
+	return append(rateArgs(rate), rate.Source)
+}
+
 type Client struct {
 	db *sqlx.DB
+
+	mu        sync.RWMutex
+	listeners []func()
 }
 
 func New(db *sqlx.DB) *Client {
@@ -72,7 +117,7 @@ func New(db *sqlx.DB) *Client {
 }
 
 func (c *Client) Migrate(ctx context.Context) error {
-	logger := slog.With("component", "db")
+	logger := slog.With("component", "database")
 	logger.DebugContext(ctx, "Running DB migrations")
 
 	for _, query := range schemas {
@@ -82,13 +127,30 @@ func (c *Client) Migrate(ctx context.Context) error {
 		}
 	}
 
-	return nil
+	return c.runMigrations(ctx)
+}
+
+// OnRatesChanged registers fn to be called after rates are written through this client.
+func (c *Client) OnRatesChanged(fn func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.listeners = append(c.listeners, fn)
+}
+
+func (c *Client) notifyRatesChanged() {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, fn := range c.listeners {
+		fn()
+	}
 }
 
 func (c *Client) StoreRate(ctx context.Context, rate entity.Rate) error {
 	_, err := c.db.ExecContext(ctx, `
-		INSERT INTO rates (code, value, published_at) VALUES (?, ?, ?);
-	`, rate.Code, rate.Value, rate.PublishedAt)
+		INSERT INTO rates (code, value, rate_date, published_at, base, rate_type, source) VALUES (?, ?, ?, ?, ?, ?, ?);
+	`, storedRateArgs(rate)...)
 	if err != nil {
 		var mysqlErr *mysql.MySQLError
 		if errors.As(err, &mysqlErr) {
@@ -97,17 +159,123 @@ func (c *Client) StoreRate(ctx context.Context, rate entity.Rate) error {
 			}
 		}
 
+		return err
 	}
 
-	return err
+	c.notifyRatesChanged()
+
+	return nil
 }
 
-func (c *Client) GetLatestRate(ctx context.Context, code string) (entity.Rate, error) {
+// maxPlaceholders is the most placeholders MySQL accepts in a single prepared statement.
+const maxPlaceholders = 65535
+
+// storedRateColumns is how many placeholders storedRateArgs fills per rate.
+const storedRateColumns = 7
+
+// StoreRates inserts the rates and skips those that are already stored. Rates are inserted
+// in as few statements as the placeholder limit allows. It returns how many were inserted,
+// the rest were duplicates.
+func (c *Client) StoreRates(ctx context.Context, rates []entity.Rate) (int, error) {
+	inserted, err := inChunks(rates, storedRateColumns, func(chunk []entity.Rate) (int, error) {
+		return c.storeRates(ctx, chunk)
+	})
+
+	// Chunks stored before a failing one are kept.
+	if inserted > 0 {
+		c.notifyRatesChanged()
+	}
+
+	return inserted, err
+}
+
+// inChunks calls fn with consecutive parts of rows small enough for a multi-row insert with
+// columns placeholders per row, and sums up what fn returns. It stops at the first error.
+func inChunks[T any](rows []T, columns int, fn func([]T) (int, error)) (int, error) {
+	chunkSize := maxPlaceholders / columns
+
+	var total int
+	for start := 0; start < len(rows); start += chunkSize {
+		n, err := fn(rows[start:min(start+chunkSize, len(rows))])
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+
+	return total, nil
+}
+
+func (c *Client) storeRates(ctx context.Context, rates []entity.Rate) (int, error) {
+	query := `INSERT INTO rates (code, value, rate_date, published_at, base, rate_type, source) VALUES ` +
+		strings.TrimSuffix(strings.Repeat("(?, ?, ?, ?, ?, ?, ?), ", len(rates)), ", ") +
+		// A no-op update reports 0 affected rows for duplicates, unlike INSERT IGNORE
+		// it doesn't hide other errors.
+		` ON DUPLICATE KEY UPDATE id = id;`
+
+	args := make([]any, 0, len(rates)*storedRateColumns)
+	for _, rate := range rates {
+		args = append(args, storedRateArgs(rate)...)
+	}
+
+	result, err := c.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(inserted), nil
+}
+
+// RatesMarker changes whenever rates are added or updated, by this or any other process.
+type RatesMarker struct {
+	Count     int          `db:"count"`
+	UpdatedAt sql.NullTime `db:"updated_at"`
+}
+
+func (m RatesMarker) Equal(other RatesMarker) bool {
+	return m.Count == other.Count &&
+		m.UpdatedAt.Valid == other.UpdatedAt.Valid &&
+		m.UpdatedAt.Time.Equal(other.UpdatedAt.Time)
+}
+
+func (c *Client) GetRatesMarker(ctx context.Context) (RatesMarker, error) {
+	var marker RatesMarker
+
+	err := c.db.GetContext(ctx, &marker, `
+		SELECT COUNT(*) AS count, MAX(updated_at) AS updated_at FROM rates;
+	`)
+
+	return marker, err
+}
+
+func (c *Client) GetLatestRate(ctx context.Context, series entity.Series) (entity.Rate, error) {
+	var rate Rate
+
+	err := c.db.GetContext(ctx, &rate, `
+		SELECT `+rateColumns+` FROM rates WHERE `+seriesCondition+` ORDER BY rate_date DESC, published_at DESC LIMIT 1;
+	`, seriesArgs(series)...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.Rate{}, ErrNotFound
+		}
+		return entity.Rate{}, err
+	}
+
+	return rate.ToEntity(), nil
+}
+
+// GetPreviousRate returns the newest rate of the series with a reference date before date.
+func (c *Client) GetPreviousRate(ctx context.Context, series entity.Series, date time.Time) (entity.Rate, error) {
 	var rate Rate
 
 	err := c.db.GetContext(ctx, &rate, `
-		SELECT code, value, published_at FROM rates WHERE code = ? ORDER BY published_at DESC LIMIT 1;
-	`, code)
+		SELECT `+rateColumns+` FROM rates WHERE `+seriesCondition+` AND rate_date < ? ORDER BY rate_date DESC, published_at DESC LIMIT 1;
+	`, append(seriesArgs(series), date.Format(time.DateOnly))...)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return entity.Rate{}, ErrNotFound
@@ -118,12 +286,43 @@ func (c *Client) GetLatestRate(ctx context.Context, code string) (entity.Rate, e
 	return rate.ToEntity(), nil
 }
 
-func (c *Client) GetRates(ctx context.Context, code string) ([]entity.Rate, error) {
+// GetRate returns the rate of the series with the reference date.
+func (c *Client) GetRate(ctx context.Context, series entity.Series, date time.Time) (entity.Rate, error) {
+	var rate Rate
+
+	err := c.db.GetContext(ctx, &rate, `
+		SELECT `+rateColumns+` FROM rates WHERE `+seriesCondition+` AND rate_date = ?;
+	`, append(seriesArgs(series), date.Format(time.DateOnly))...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.Rate{}, ErrNotFound
+		}
+		return entity.Rate{}, err
+	}
+
+	return rate.ToEntity(), nil
+}
+
+// GetRateDates returns the reference dates of the stored rates of the series from since on.
+func (c *Client) GetRateDates(ctx context.Context, series entity.Series, since time.Time) ([]time.Time, error) {
+	var dates []time.Time
+
+	err := c.db.SelectContext(ctx, &dates, `
+		SELECT DISTINCT rate_date FROM rates WHERE `+seriesCondition+` AND rate_date >= ?;
+	`, append(seriesArgs(series), since.Format(time.DateOnly))...)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Map(dates, func(date time.Time) time.Time { return date.UTC() }), nil
+}
+
+func (c *Client) GetRates(ctx context.Context, series entity.Series) ([]entity.Rate, error) {
 	var rates []Rate
 
 	err := c.db.SelectContext(ctx, &rates, `
-		SELECT code, value, published_at FROM rates WHERE code = ? ORDER BY published_at DESC;
-	`, code)
+		SELECT `+rateColumns+` FROM rates WHERE `+seriesCondition+` ORDER BY rate_date DESC, published_at DESC;
+	`, seriesArgs(series)...)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrNotFound
@@ -133,3 +332,137 @@ func (c *Client) GetRates(ctx context.Context, code string) ([]entity.Rate, erro
 
 	return slices.Map(rates, func(r Rate) entity.Rate { return r.ToEntity() }), nil
 }
+
+// RateFilter narrows down which rates are read from the database.
+// Zero values mean no restriction on that field.
+type RateFilter struct {
+	Bases []string
+	Codes []string
+	Types []entity.RateType
+	// From and To limit the reference dates, From is inclusive and To exclusive.
+	From time.Time
+	To   time.Time
+}
+
+func (f RateFilter) where() (string, []any, error) {
+	var (
+		conditions []string
+		args       []any
+	)
+
+	in := func(column string, values any) error {
+		query, inArgs, err := sqlx.In(column+" IN (?)", values)
+		if err != nil {
+			return err
+		}
+		conditions = append(conditions, query)
+		args = append(args, inArgs...)
+		return nil
+	}
+
+	if len(f.Bases) > 0 {
+		if err := in("base", f.Bases); err != nil {
+			return "", nil, err
+		}
+	}
+
+	if len(f.Codes) > 0 {
+		if err := in("code", f.Codes); err != nil {
+			return "", nil, err
+		}
+	}
+
+	if len(f.Types) > 0 {
+		types := slices.Map(f.Types, func(t entity.RateType) string { return string(t) })
+		if err := in("rate_type", types); err != nil {
+			return "", nil, err
+		}
+	}
+
+	if !f.From.IsZero() {
+		conditions = append(conditions, "rate_date >= ?")
+		args = append(args, f.From.Format(time.DateOnly))
+	}
+
+	if !f.To.IsZero() {
+		conditions = append(conditions, "rate_date < ?")
+		args = append(args, f.To.Format(time.DateOnly))
+	}
+
+	if len(conditions) == 0 {
+		return "", nil, nil
+	}
+
+	return "WHERE " + strings.Join(conditions, " AND "), args, nil
+}
+
+// EachRate streams the rates matching the filter and calls fn for each of them.
+// Unlike GetRates, rows are read with a cursor so the result set is never held in memory.
+// Iteration stops at the first error returned by fn.
+func (c *Client) EachRate(ctx context.Context, filter RateFilter, fn func(entity.Rate) error) error {
+	where, args, err := filter.where()
+	if err != nil {
+		return fmt.Errorf("failed to build filter: %w", err)
+	}
+
+	rows, err := c.db.QueryxContext(ctx, `
+		SELECT `+rateColumns+` FROM rates `+where+` ORDER BY base, code, rate_type, rate_date, published_at;
+	`, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var rate Rate
+		if err := rows.StructScan(&rate); err != nil {
+			return err
+		}
+
+		if err := fn(rate.ToEntity()); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (c *Client) Ping(ctx context.Context) error {
+	return c.db.PingContext(ctx)
+}
+
+// LatestPublication is the newest stored rate of a currency.
+type LatestPublication struct {
+	// Date is the newest reference date.
+	Date time.Time `db:"rate_date"`
+	// PublishedAt is the newest publication time, which may belong to a correction of an older date.
+	PublishedAt time.Time `db:"published_at"`
+}
+
+// GetLatestPublications returns the newest reference date and publication time for each of the
+// codes that has any rates. Only the ECB reference rates count, they are the ones published on
+// the ECB calendar.
+func (c *Client) GetLatestPublications(ctx context.Context, codes []string) (map[string]LatestPublication, error) {
+	query, args, err := sqlx.In(`
+		SELECT code, MAX(rate_date) AS rate_date, MAX(published_at) AS published_at FROM rates
+		WHERE base = ? AND rate_type = ? AND code IN (?) GROUP BY code;
+	`, entity.DefaultBase, string(entity.RateTypeReference), codes)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		Code string `db:"code"`
+		LatestPublication
+	}
+	if err := c.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, err
+	}
+
+	latest := make(map[string]LatestPublication, len(rows))
+	for _, row := range rows {
+		latest[row.Code] = LatestPublication{Date: row.Date.UTC(), PublishedAt: row.PublishedAt.UTC()}
+	}
+
+	return latest, nil
+}
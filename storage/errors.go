@@ -5,4 +5,6 @@ import "errors"
 var (
 	ErrDuplicate = errors.New("duplicate entry")
 	ErrNotFound  = errors.New("not found")
+	// ErrAlreadyReviewed is returned when a quarantined rate was approved or rejected before.
+	ErrAlreadyReviewed = errors.New("already reviewed")
 )
@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/zemzale/backscreen-home/domain/entity"
+)
+
+// createImportDataTable is the original shape of the table, migration 2 adds the feed columns.
+const createImportDataTable = `
+CREATE TABLE IF NOT EXISTS import_data (
+	id INT NOT NULL AUTO_INCREMENT,
+	data TEXT NOT NULL,
+	source VARCHAR(255) NOT NULL,
+	created_at DATETIME NOT NULL,
+	updated_at DATETIME NOT NULL,
+	PRIMARY KEY (id)
+);
+`
+
+// StoreImport keeps the raw items of the feed with their guid, so stored rates can be traced
+// back to the feed entry they came from by source and published_at. Items that were imported
+// before keep their data and only get the newer build date.
+func (c *Client) StoreImport(ctx context.Context, feed entity.Feed) error {
+	if len(feed.Items) == 0 {
+		return nil
+	}
+
+	// NULL when the feed has no build date, so it never looks like the feed is unchanged.
+	var buildDate any
+	if !feed.BuildDate.IsZero() {
+		buildDate = feed.BuildDate.UTC()
+	}
+
+	query := `INSERT INTO import_data (source, guid, published_at, data, feed_built_at) VALUES ` +
+		strings.TrimSuffix(strings.Repeat("(?, ?, ?, ?, ?), ", len(feed.Items)), ", ") +
+		` ON DUPLICATE KEY UPDATE feed_built_at = COALESCE(VALUES(feed_built_at), feed_built_at);`
+
+	args := make([]any, 0, len(feed.Items)*5)
+	for _, item := range feed.Items {
+		args = append(args, feed.Source, item.GUID, item.PublishedAt.UTC(), item.Raw, buildDate)
+	}
+
+	_, err := c.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+// GetLatestFeedBuild returns the newest build date of the imported feeds of the source,
+// zero when none was imported yet.
+func (c *Client) GetLatestFeedBuild(ctx context.Context, source string) (time.Time, error) {
+	var buildDate sql.NullTime
+
+	err := c.db.GetContext(ctx, &buildDate, `
+		SELECT MAX(feed_built_at) FROM import_data WHERE source = ?;
+	`, source)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return buildDate.Time.UTC(), nil
+}
@@ -0,0 +1,389 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/zemzale/backscreen-home/domain/ecb"
+)
+
+const createSchemaMigrationsTable = `
+CREATE TABLE IF NOT EXISTS schema_migrations (
+	version INT NOT NULL,
+	name VARCHAR(255) NOT NULL,
+	applied_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (version)
+);`
+
+// migrationLock serializes migrations when the API and the syncer start at the same time.
+const migrationLock = "backscreen_home_migrations"
+
+// migration changes the schema after the tables in schemas were created. MySQL commits DDL
+// statements right away, so every step must be safe to run again after a partial failure.
+type migration struct {
+	version int
+	name    string
+	up      func(ctx context.Context, db *sqlx.Conn) error
+}
+
+// migrations are applied in order, each of them once. Never change or reorder released ones.
+var migrations = []migration{
+	{version: 1, name: "add rates.rate_date", up: addRateDate},
+	{version: 2, name: "add import_data feed columns", up: addImportFeedColumns},
+	{version: 3, name: "add quarantined_rates review columns", up: addQuarantineReviewColumns},
+	{version: 4, name: "make rates unique per code and rate_date", up: uniqueRateDate},
+	{version: 5, name: "add rates.source", up: addRateSource},
+	{version: 6, name: "add base and rate_type to rates", up: addRateSeries},
+}
+
+func (c *Client) runMigrations(ctx context.Context) error {
+	logger := slog.With("component", "database")
+
+	// The lock belongs to the connection, so everything runs on the same one.
+	conn, err := c.db.Connx(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var locked sql.NullInt64
+	if err := conn.GetContext(ctx, &locked, "SELECT GET_LOCK(?, 60);", migrationLock); err != nil {
+		return fmt.Errorf("failed to lock migrations: %w", err)
+	}
+	if !locked.Valid || locked.Int64 != 1 {
+		return fmt.Errorf("timed out waiting for another process to finish migrating")
+	}
+	defer conn.ExecContext(context.WithoutCancel(ctx), "SELECT RELEASE_LOCK(?);", migrationLock)
+
+	var applied []int
+	if err := conn.SelectContext(ctx, &applied, "SELECT version FROM schema_migrations;"); err != nil {
+		return err
+	}
+
+	done := make(map[int]bool, len(applied))
+	for _, version := range applied {
+		done[version] = true
+	}
+
+	for _, m := range migrations {
+		if done[m.version] {
+			continue
+		}
+
+		logger.InfoContext(ctx, "Applying migration", slog.Int("version", m.version), slog.String("name", m.name))
+		if err := m.up(ctx, conn); err != nil {
+			return fmt.Errorf("migration %d (%s) failed: %w", m.version, m.name, err)
+		}
+
+		if _, err := conn.ExecContext(ctx, "INSERT INTO schema_migrations (version, name) VALUES (?, ?);", m.version, m.name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func columnExists(ctx context.Context, db *sqlx.Conn, table, column string) (bool, error) {
+	var count int
+	err := db.GetContext(ctx, &count, `
+		SELECT COUNT(*) FROM information_schema.columns
+		WHERE table_schema = DATABASE() AND table_name = ? AND column_name = ?;
+	`, table, column)
+	return count > 0, err
+}
+
+func indexExists(ctx context.Context, db *sqlx.Conn, table, index string) (bool, error) {
+	var count int
+	err := db.GetContext(ctx, &count, `
+		SELECT COUNT(*) FROM information_schema.statistics
+		WHERE table_schema = DATABASE() AND table_name = ? AND index_name = ?;
+	`, table, index)
+	return count > 0, err
+}
+
+// indexOn returns the name of the index on exactly the columns in order, or an empty string
+// when there is none. The indexes created with the tables have generated names.
+func indexOn(ctx context.Context, db *sqlx.Conn, table string, columns ...string) (string, error) {
+	var names []string
+	err := db.SelectContext(ctx, &names, `
+		SELECT index_name FROM information_schema.statistics
+		WHERE table_schema = DATABASE() AND table_name = ?
+		GROUP BY index_name
+		HAVING GROUP_CONCAT(column_name ORDER BY seq_in_index) = ?;
+	`, table, strings.Join(columns, ","))
+	if err != nil || len(names) == 0 {
+		return "", err
+	}
+	return names[0], nil
+}
+
+// replaceUniqueIndex adds the unique index name on columns and drops the index on the old columns.
+func replaceUniqueIndex(ctx context.Context, db *sqlx.Conn, table string, old []string, name string, columns ...string) error {
+	exists, err := indexExists(ctx, db, table, name)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		query := "CREATE UNIQUE INDEX " + name + " ON " + table + " (" + strings.Join(columns, ", ") + ");"
+		if _, err := db.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	oldName, err := indexOn(ctx, db, table, old...)
+	if err != nil || oldName == "" || oldName == name {
+		return err
+	}
+	_, err = db.ExecContext(ctx, "DROP INDEX `"+oldName+"` ON "+table+";")
+	return err
+}
+
+// addColumn adds the column unless a partial run of the migration added it already.
+func addColumn(ctx context.Context, db *sqlx.Conn, table, column, definition string) error {
+	exists, err := columnExists(ctx, db, table, column)
+	if err != nil || exists {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, "ALTER TABLE "+table+" ADD COLUMN "+column+" "+definition+";")
+	return err
+}
+
+// backfillBatchSize limits how many rows are read into memory while backfilling.
+const backfillBatchSize = 1000
+
+// addRateDate adds the ECB reference date of every rate. The dates are computed with
+// ecb.ReferenceDate, the same way new rates get them, instead of in SQL.
+func addRateDate(ctx context.Context, db *sqlx.Conn) error {
+	if err := addColumn(ctx, db, "rates", "rate_date", "DATE NULL AFTER value"); err != nil {
+		return err
+	}
+
+	for {
+		// published_at has always been written in UTC, the driver converts to its location before sending.
+		var rows []struct {
+			ID          int       `db:"id"`
+			PublishedAt time.Time `db:"published_at"`
+		}
+		err := db.SelectContext(ctx, &rows, "SELECT id, published_at FROM rates WHERE rate_date IS NULL ORDER BY id LIMIT ?;", backfillBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			break
+		}
+
+		byDate := map[time.Time][]int{}
+		for _, row := range rows {
+			date := ecb.ReferenceDate(row.PublishedAt)
+			byDate[date] = append(byDate[date], row.ID)
+		}
+
+		for date, ids := range byDate {
+			query, args, err := sqlx.In("UPDATE rates SET rate_date = ? WHERE id IN (?);", date.Format(time.DateOnly), ids)
+			if err != nil {
+				return err
+			}
+			if _, err := db.ExecContext(ctx, query, args...); err != nil {
+				return err
+			}
+		}
+	}
+
+	if _, err := db.ExecContext(ctx, "ALTER TABLE rates MODIFY rate_date DATE NOT NULL;"); err != nil {
+		return err
+	}
+
+	exists, err := indexExists(ctx, db, "rates", "rates_code_rate_date")
+	if err != nil {
+		return err
+	}
+	if !exists {
+		if _, err := db.ExecContext(ctx, "CREATE INDEX rates_code_rate_date ON rates (code, rate_date);"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addImportFeedColumns lets import_data hold the raw feed items. Nothing wrote to the table
+// before, so there is nothing to backfill.
+func addImportFeedColumns(ctx context.Context, db *sqlx.Conn) error {
+	columns := []struct {
+		name       string
+		definition string
+	}{
+		{name: "guid", definition: "VARCHAR(255) NOT NULL DEFAULT '' AFTER source"},
+		{name: "published_at", definition: "DATETIME NULL AFTER guid"},
+		{name: "feed_built_at", definition: "DATETIME NULL AFTER published_at"},
+	}
+
+	for _, column := range columns {
+		if err := addColumn(ctx, db, "import_data", column.name, column.definition); err != nil {
+			return err
+		}
+	}
+
+	if _, err := db.ExecContext(ctx, `
+		ALTER TABLE import_data
+			MODIFY created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			MODIFY updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP;
+	`); err != nil {
+		return err
+	}
+
+	exists, err := indexExists(ctx, db, "import_data", "import_data_source_published_at")
+	if err != nil {
+		return err
+	}
+	if !exists {
+		if _, err := db.ExecContext(ctx, "CREATE UNIQUE INDEX import_data_source_published_at ON import_data (source, published_at);"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addQuarantineReviewColumns records who reviewed a quarantined rate and why. The table is
+// created with the columns now, so this only upgrades databases from before they existed.
+func addQuarantineReviewColumns(ctx context.Context, db *sqlx.Conn) error {
+	columns := []struct {
+		name       string
+		definition string
+	}{
+		{name: "reviewed_by", definition: "VARCHAR(255) NULL AFTER status"},
+		{name: "review_reason", definition: "VARCHAR(1024) NULL AFTER reviewed_by"},
+		{name: "reviewed_at", definition: "DATETIME NULL AFTER review_reason"},
+	}
+
+	for _, column := range columns {
+		if err := addColumn(ctx, db, "quarantined_rates", column.name, column.definition); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// uniqueRateDate makes the reference date the identity of a rate, so sources publishing the
+// same date at different times can't store it twice. Rates that already share a date are never
+// deleted here, the migration fails with the list of them until they are cleaned up by hand.
+func uniqueRateDate(ctx context.Context, db *sqlx.Conn) error {
+	exists, err := indexExists(ctx, db, "rates", "rates_code_rate_date_unique")
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		var duplicates []struct {
+			Code     string    `db:"code"`
+			RateDate time.Time `db:"rate_date"`
+			IDs      string    `db:"ids"`
+		}
+		err := db.SelectContext(ctx, &duplicates, `
+			SELECT code, rate_date, GROUP_CONCAT(id ORDER BY id SEPARATOR ', ') AS ids FROM rates
+			GROUP BY code, rate_date HAVING COUNT(*) > 1 ORDER BY code, rate_date;
+		`)
+		if err != nil {
+			return err
+		}
+		if len(duplicates) > 0 {
+			lines := make([]string, 0, len(duplicates))
+			for _, duplicate := range duplicates {
+				lines = append(lines, fmt.Sprintf("%s %s (ids %s)", duplicate.Code, duplicate.RateDate.Format(time.DateOnly), duplicate.IDs))
+			}
+			return fmt.Errorf("%d reference dates have more than one rate, keep one rate of each and migrate again: %s",
+				len(duplicates), strings.Join(lines, "; "))
+		}
+
+		if _, err := db.ExecContext(ctx, "CREATE UNIQUE INDEX rates_code_rate_date_unique ON rates (code, rate_date);"); err != nil {
+			return err
+		}
+	}
+
+	// The unique index covers the lookups the plain one was added for.
+	exists, err = indexExists(ctx, db, "rates", "rates_code_rate_date")
+	if err != nil || !exists {
+		return err
+	}
+	_, err = db.ExecContext(ctx, "DROP INDEX rates_code_rate_date ON rates;")
+	return err
+}
+
+// addRateSource records which source every rate came from. Before it was recorded, the sync
+// and the import only read bank.lv feeds, so existing rates are marked as lvbank.
+func addRateSource(ctx context.Context, db *sqlx.Conn) error {
+	if err := addColumn(ctx, db, "rates", "source", "VARCHAR(255) NOT NULL DEFAULT 'lvbank' AFTER published_at"); err != nil {
+		return err
+	}
+
+	// The default only backfills the existing rows, new ones always name their source.
+	_, err := db.ExecContext(ctx, "ALTER TABLE rates ALTER source DROP DEFAULT;")
+	return err
+}
+
+// addRateSeries adds the base currency and rate type to every table holding rates and makes
+// them part of the unique keys, so rates of other bases and types don't collide with the ECB
+// reference rates. Every rate stored before is an ECB reference rate against the euro.
+func addRateSeries(ctx context.Context, db *sqlx.Conn) error {
+	for _, table := range []string{"rates", "quarantined_rates", "rate_sources"} {
+		if err := addColumn(ctx, db, table, "base", "VARCHAR(3) NOT NULL DEFAULT 'EUR' AFTER published_at"); err != nil {
+			return err
+		}
+		if err := addColumn(ctx, db, table, "rate_type", "VARCHAR(16) NOT NULL DEFAULT 'reference' AFTER base"); err != nil {
+			return err
+		}
+
+		// Like with rates.source, the defaults only backfill the existing rows.
+		if _, err := db.ExecContext(ctx, "ALTER TABLE "+table+" ALTER base DROP DEFAULT, ALTER rate_type DROP DEFAULT;"); err != nil {
+			return err
+		}
+	}
+
+	indexes := []struct {
+		table   string
+		old     []string
+		name    string
+		columns []string
+	}{
+		{
+			table:   "rates",
+			old:     []string{"code", "published_at"},
+			name:    "rates_series_published_at_unique",
+			columns: []string{"base", "code", "rate_type", "published_at"},
+		},
+		{
+			table:   "rates",
+			old:     []string{"code", "rate_date"},
+			name:    "rates_series_rate_date_unique",
+			columns: []string{"base", "code", "rate_type", "rate_date"},
+		},
+		{
+			table:   "quarantined_rates",
+			old:     []string{"code", "published_at"},
+			name:    "quarantined_rates_series_published_at_unique",
+			columns: []string{"base", "code", "rate_type", "published_at"},
+		},
+		{
+			table:   "rate_sources",
+			old:     []string{"code", "rate_date", "source"},
+			name:    "rate_sources_series_rate_date_source_unique",
+			columns: []string{"base", "code", "rate_type", "rate_date", "source"},
+		},
+	}
+
+	for _, index := range indexes {
+		if err := replaceUniqueIndex(ctx, db, index.table, index.old, index.name, index.columns...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+)
+
+func TestUniqueRateDateKeepsDuplicates(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer db.Close()
+
+	conn, err := sqlx.NewDb(db, "mysql").Connx(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer conn.Close()
+
+	// The mock fails any other statement, so nothing is deleted and no index is created.
+	mock.ExpectQuery(regexp.QuoteMeta("FROM information_schema.statistics")).
+		WithArgs("rates", "rates_code_rate_date_unique").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(regexp.QuoteMeta("HAVING COUNT(*) > 1")).
+		WillReturnRows(sqlmock.NewRows([]string{"code", "rate_date", "ids"}).
+			AddRow("GBP", time.Date(2025, time.October, 9, 0, 0, 0, 0, time.UTC), "4, 5, 9").
+			AddRow("USD", time.Date(2025, time.October, 10, 0, 0, 0, 0, time.UTC), "3, 7"))
+
+	err = uniqueRateDate(context.Background(), conn)
+	if err == nil {
+		t.Fatal("Expected the duplicates to fail the migration, got no error")
+	}
+	for _, want := range []string{"2 reference dates", "GBP 2025-10-09 (ids 4, 5, 9)", "USD 2025-10-10 (ids 3, 7)"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected the error to contain %q, got %q", want, err)
+		}
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
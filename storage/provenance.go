@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/zemzale/backscreen-home/domain/entity"
+	"github.com/zemzale/backscreen-home/slices"
+)
+
+const createRateSourcesTable = `
+CREATE TABLE IF NOT EXISTS rate_sources (
+	id INT NOT NULL AUTO_INCREMENT,
+	code VARCHAR(3) NOT NULL,
+	rate_date DATE NOT NULL,
+	source VARCHAR(255) NOT NULL,
+	value VARCHAR(100) NOT NULL,
+	published_at DATETIME NOT NULL,
+	base VARCHAR(3) NOT NULL,
+	rate_type VARCHAR(16) NOT NULL,
+	agrees BOOLEAN NOT NULL,
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+	PRIMARY KEY (id),
+	UNIQUE INDEX rate_sources_series_rate_date_source_unique (base, code, rate_type, rate_date, source)
+);`
+
+type SourceRate struct {
+	Code        string    `db:"code"`
+	RateDate    time.Time `db:"rate_date"`
+	Source      string    `db:"source"`
+	Value       string    `db:"value"`
+	PublishedAt time.Time `db:"published_at"`
+	Base        string    `db:"base"`
+	RateType    string    `db:"rate_type"`
+	Agrees      bool      `db:"agrees"`
+}
+
+func (s SourceRate) ToEntity() entity.SourceRate {
+	return entity.SourceRate{
+		Source: s.Source,
+		Rate: Rate{
+			Code:        s.Code,
+			Value:       s.Value,
+			RateDate:    s.RateDate,
+			PublishedAt: s.PublishedAt,
+			Base:        s.Base,
+			RateType:    s.RateType,
+		}.ToEntity(),
+		Agrees: s.Agrees,
+	}
+}
+
+// StoreSourceRates keeps what every source published. A source that publishes again for the
+// same currency and date replaces its earlier value.
+func (c *Client) StoreSourceRates(ctx context.Context, rates []entity.SourceRate) error {
+	_, err := inChunks(rates, sourceRateColumns, func(chunk []entity.SourceRate) (int, error) {
+		return 0, c.storeSourceRates(ctx, chunk)
+	})
+	return err
+}
+
+// sourceRateColumns is how many placeholders a source rate fills in an insert.
+const sourceRateColumns = 8
+
+func (c *Client) storeSourceRates(ctx context.Context, rates []entity.SourceRate) error {
+	query := `INSERT INTO rate_sources (code, value, rate_date, published_at, base, rate_type, source, agrees) VALUES ` +
+		strings.TrimSuffix(strings.Repeat("(?, ?, ?, ?, ?, ?, ?, ?), ", len(rates)), ", ") +
+		` ON DUPLICATE KEY UPDATE value = VALUES(value), published_at = VALUES(published_at), agrees = VALUES(agrees);`
+
+	args := make([]any, 0, len(rates)*sourceRateColumns)
+	for _, rate := range rates {
+		args = append(args, rateArgs(rate.Rate)...)
+		args = append(args, rate.Source, rate.Agrees)
+	}
+
+	_, err := c.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+// GetSourceRates returns what each source published for the series on the reference date.
+func (c *Client) GetSourceRates(ctx context.Context, series entity.Series, date time.Time) ([]entity.SourceRate, error) {
+	var rates []SourceRate
+
+	err := c.db.SelectContext(ctx, &rates, `
+		SELECT code, rate_date, base, rate_type, source, value, published_at, agrees FROM rate_sources WHERE `+seriesCondition+` AND rate_date = ? ORDER BY source;
+	`, append(seriesArgs(series), date.Format(time.DateOnly))...)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Map(rates, func(s SourceRate) entity.SourceRate { return s.ToEntity() }), nil
+}
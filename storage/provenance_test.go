@@ -0,0 +1,39 @@
+package storage
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/zemzale/backscreen-home/domain/entity"
+)
+
+func TestStoreSourceRatesChunks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer db.Close()
+
+	perChunk := maxPlaceholders / sourceRateColumns
+	rates := make([]entity.SourceRate, 2*perChunk+1)
+	for i := range rates {
+		date := time.Date(2025, time.October, 10, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -i)
+		rates[i] = entity.SourceRate{Source: "ecb", Rate: entity.Rate{Code: "USD", Value: "1.1568", Date: date, PublishedAt: date.Add(14 * time.Hour)}, Agrees: true}
+	}
+
+	insert := regexp.QuoteMeta("INSERT INTO rate_sources")
+	for range 3 {
+		mock.ExpectExec(insert).WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+
+	if err := New(sqlx.NewDb(db, "mysql")).StoreSourceRates(context.Background(), rates); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
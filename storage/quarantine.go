@@ -0,0 +1,214 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/zemzale/backscreen-home/domain/entity"
+	"github.com/zemzale/backscreen-home/slices"
+)
+
+const createQuarantinedRatesTable = `
+CREATE TABLE IF NOT EXISTS quarantined_rates (
+	id INT NOT NULL AUTO_INCREMENT,
+	code VARCHAR(3) NOT NULL,
+	value VARCHAR(100) NOT NULL,
+	rate_date DATE NOT NULL,
+	published_at DATETIME NOT NULL,
+	base VARCHAR(3) NOT NULL,
+	rate_type VARCHAR(16) NOT NULL,
+	source VARCHAR(255) NOT NULL,
+	reason VARCHAR(1024) NOT NULL,
+	status VARCHAR(16) NOT NULL DEFAULT 'pending',
+	reviewed_by VARCHAR(255) NULL,
+	review_reason VARCHAR(1024) NULL,
+	reviewed_at DATETIME NULL,
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+	PRIMARY KEY (id),
+	UNIQUE INDEX quarantined_rates_series_published_at_unique (base, code, rate_type, published_at),
+	INDEX (status)
+);`
+
+const quarantinedRateColumns = `id, code, value, rate_date, published_at, base, rate_type, source, reason, status, created_at, reviewed_by, review_reason, reviewed_at`
+
+type QuarantinedRate struct {
+	ID           int            `db:"id"`
+	Code         string         `db:"code"`
+	Value        string         `db:"value"`
+	RateDate     time.Time      `db:"rate_date"`
+	PublishedAt  time.Time      `db:"published_at"`
+	Base         string         `db:"base"`
+	RateType     string         `db:"rate_type"`
+	Source       string         `db:"source"`
+	Reason       string         `db:"reason"`
+	Status       string         `db:"status"`
+	CreatedAt    time.Time      `db:"created_at"`
+	ReviewedBy   sql.NullString `db:"reviewed_by"`
+	ReviewReason sql.NullString `db:"review_reason"`
+	ReviewedAt   sql.NullTime   `db:"reviewed_at"`
+}
+
+func (q QuarantinedRate) ToEntity() entity.QuarantinedRate {
+	rate := entity.QuarantinedRate{
+		ID: q.ID,
+		Rate: Rate{
+			Code:        q.Code,
+			Value:       q.Value,
+			RateDate:    q.RateDate,
+			PublishedAt: q.PublishedAt,
+			Base:        q.Base,
+			RateType:    q.RateType,
+		}.ToEntity(),
+		Source:       q.Source,
+		Reason:       q.Reason,
+		Status:       entity.QuarantineStatus(q.Status),
+		CreatedAt:    q.CreatedAt.UTC(),
+		ReviewedBy:   q.ReviewedBy.String,
+		ReviewReason: q.ReviewReason.String,
+	}
+
+	if q.ReviewedAt.Valid {
+		reviewedAt := q.ReviewedAt.Time.UTC()
+		rate.ReviewedAt = &reviewedAt
+	}
+
+	return rate
+}
+
+// QuarantineRates keeps the suspect rates for review. A rate that is already quarantined is
+// skipped, it returns how many were added.
+func (c *Client) QuarantineRates(ctx context.Context, rates []entity.QuarantinedRate) (int, error) {
+	return inChunks(rates, quarantinedRateInsertColumns, func(chunk []entity.QuarantinedRate) (int, error) {
+		return c.quarantineRates(ctx, chunk)
+	})
+}
+
+// quarantinedRateInsertColumns is how many placeholders a quarantined rate fills in an insert.
+const quarantinedRateInsertColumns = 8
+
+func (c *Client) quarantineRates(ctx context.Context, rates []entity.QuarantinedRate) (int, error) {
+	query := `INSERT INTO quarantined_rates (code, value, rate_date, published_at, base, rate_type, source, reason) VALUES ` +
+		strings.TrimSuffix(strings.Repeat("(?, ?, ?, ?, ?, ?, ?, ?), ", len(rates)), ", ") +
+		` ON DUPLICATE KEY UPDATE id = id;`
+
+	args := make([]any, 0, len(rates)*quarantinedRateInsertColumns)
+	for _, rate := range rates {
+		args = append(args, rateArgs(rate.Rate)...)
+		args = append(args, rate.Source, rate.Reason)
+	}
+
+	result, err := c.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	inserted, err := result.RowsAffected()
+	return int(inserted), err
+}
+
+// ListQuarantinedRates returns the quarantined rates with the status, newest first.
+func (c *Client) ListQuarantinedRates(ctx context.Context, status entity.QuarantineStatus) ([]entity.QuarantinedRate, error) {
+	var rates []QuarantinedRate
+
+	err := c.db.SelectContext(ctx, &rates, `
+		SELECT `+quarantinedRateColumns+` FROM quarantined_rates WHERE status = ? ORDER BY id DESC;
+	`, string(status))
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Map(rates, func(q QuarantinedRate) entity.QuarantinedRate { return q.ToEntity() }), nil
+}
+
+func (c *Client) CountQuarantinedRates(ctx context.Context, status entity.QuarantineStatus) (int, error) {
+	var count int
+	err := c.db.GetContext(ctx, &count, `SELECT COUNT(*) FROM quarantined_rates WHERE status = ?;`, string(status))
+	return count, err
+}
+
+// ReviewQuarantinedRate approves or rejects a pending rate. Approved rates are stored with the
+// other rates in the same transaction. Rates that were reviewed already return ErrAlreadyReviewed,
+// and approving a rate whose date is already stored returns ErrDuplicate and leaves it pending.
+func (c *Client) ReviewQuarantinedRate(ctx context.Context, id int, status entity.QuarantineStatus, reviewer, reason string) (entity.QuarantinedRate, error) {
+	tx, err := c.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return entity.QuarantinedRate{}, err
+	}
+	defer tx.Rollback()
+
+	quarantined, err := getQuarantinedRate(ctx, tx, id, true)
+	if err != nil {
+		return entity.QuarantinedRate{}, err
+	}
+	if quarantined.Status != entity.QuarantinePending {
+		return quarantined, ErrAlreadyReviewed
+	}
+
+	if status == entity.QuarantineApproved {
+		rate := quarantined.Rate
+		rate.Source = quarantined.Source
+
+		result, err := tx.ExecContext(ctx, `
+			INSERT INTO rates (code, value, rate_date, published_at, base, rate_type, source) VALUES (?, ?, ?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE id = id;
+		`, storedRateArgs(rate)...)
+		if err != nil {
+			return entity.QuarantinedRate{}, err
+		}
+		inserted, err := result.RowsAffected()
+		if err != nil {
+			return entity.QuarantinedRate{}, err
+		}
+		if inserted == 0 {
+			// A rate stored for the same reference date in the meantime is kept, and the
+			// quarantined one stays pending since its value didn't go live.
+			return quarantined, fmt.Errorf("a rate of %s is already stored for %s: %w",
+				rate.Series(), rate.Date.Format(time.DateOnly), ErrDuplicate)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE quarantined_rates SET status = ?, reviewed_by = ?, review_reason = ?, reviewed_at = ? WHERE id = ?;
+	`, string(status), reviewer, reason, time.Now().UTC(), id)
+	if err != nil {
+		return entity.QuarantinedRate{}, err
+	}
+
+	quarantined, err = getQuarantinedRate(ctx, tx, id, false)
+	if err != nil {
+		return entity.QuarantinedRate{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return entity.QuarantinedRate{}, err
+	}
+
+	if status == entity.QuarantineApproved {
+		c.notifyRatesChanged()
+	}
+
+	return quarantined, nil
+}
+
+func getQuarantinedRate(ctx context.Context, tx *sqlx.Tx, id int, lock bool) (entity.QuarantinedRate, error) {
+	query := `SELECT ` + quarantinedRateColumns + ` FROM quarantined_rates WHERE id = ?`
+	if lock {
+		// Concurrent reviews of the same rate wait for each other instead of both passing the status check.
+		query += ` FOR UPDATE`
+	}
+
+	var rate QuarantinedRate
+	if err := tx.GetContext(ctx, &rate, query+`;`, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.QuarantinedRate{}, ErrNotFound
+		}
+		return entity.QuarantinedRate{}, err
+	}
+
+	return rate.ToEntity(), nil
+}
@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/zemzale/backscreen-home/domain/entity"
+)
+
+func TestQuarantineRatesChunks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer db.Close()
+
+	perChunk := maxPlaceholders / quarantinedRateInsertColumns
+	rates := make([]entity.QuarantinedRate, perChunk+1)
+	for i := range rates {
+		publishedAt := time.Date(2025, time.October, 10, 14, 0, i, 0, time.UTC)
+		rates[i] = entity.QuarantinedRate{Rate: entity.Rate{Code: "USD", Value: "1.1568", PublishedAt: publishedAt}, Source: "ecb", Reason: "test"}
+	}
+
+	insert := regexp.QuoteMeta("INSERT INTO quarantined_rates")
+	mock.ExpectExec(insert).WillReturnResult(sqlmock.NewResult(0, int64(perChunk)))
+	mock.ExpectExec(insert).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	quarantined, err := New(sqlx.NewDb(db, "mysql")).QuarantineRates(context.Background(), rates)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if quarantined != len(rates) {
+		t.Errorf("Expected %d rates quarantined, got %d", len(rates), quarantined)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}